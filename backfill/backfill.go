@@ -0,0 +1,126 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backfill
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kube-logging/log-generator/conf"
+)
+
+// Config configures the historical window of a backfill.
+type Config struct {
+	Start time.Time
+	End   time.Time
+	// Rate is the number of ticks per second of simulated time, every tick
+	// emits an event of each stream.
+	Rate float64
+	// Speed is the number of simulated seconds per real second, ticks come
+	// as fast as the writers allow if 0.
+	Speed float64
+}
+
+// parseTime returns an RFC 3339 time, or the time a duration like 168h before
+// end.
+func parseTime(s string, end time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if d, err := time.ParseDuration(s); err == nil {
+		return end.Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// Backfill generates the ticks of a historical window with simulated
+// timestamps.
+type Backfill struct {
+	config Config
+}
+
+// FromConfig returns the Backfill configured by the [backfill] config
+// section, or nil if it is disabled.
+func FromConfig() (*Backfill, error) {
+	if !conf.Viper.GetBool("backfill.enabled") {
+		return nil, nil
+	}
+
+	config := Config{
+		End:   time.Now(),
+		Rate:  conf.Viper.GetFloat64("backfill.rate"),
+		Speed: conf.Viper.GetFloat64("backfill.speed"),
+	}
+	if end := conf.Viper.GetString("backfill.end"); end != "" {
+		var err error
+		if config.End, err = time.Parse(time.RFC3339, end); err != nil {
+			return nil, fmt.Errorf("invalid end: %w", err)
+		}
+	}
+	start, err := parseTime(conf.Viper.GetString("backfill.start"), config.End)
+	if err != nil {
+		return nil, fmt.Errorf("invalid start: %w", err)
+	}
+	config.Start = start
+
+	return New(config)
+}
+
+func New(config Config) (*Backfill, error) {
+	if !config.Start.Before(config.End) {
+		return nil, fmt.Errorf("start %v must be before end %v", config.Start, config.End)
+	}
+	if config.Rate <= 0 {
+		return nil, fmt.Errorf("rate must be positive, got %v", config.Rate)
+	}
+	if config.Speed < 0 {
+		return nil, fmt.Errorf("speed must not be negative, got %v", config.Speed)
+	}
+	return &Backfill{config: config}, nil
+}
+
+// Ticks returns the simulated times of the ticks from start to end. The
+// channel is closed after the last tick.
+func (b *Backfill) Ticks() <-chan time.Time {
+	ticks := make(chan time.Time)
+	go func() {
+		defer close(ticks)
+
+		began := time.Now()
+		for i := 0; ; i++ {
+			// computed from the start, so rounding errors do not accumulate
+			elapsed := time.Duration(float64(i) / b.config.Rate * float64(time.Second))
+			t := b.config.Start.Add(elapsed)
+			if !t.Before(b.config.End) {
+				return
+			}
+			if b.config.Speed > 0 {
+				if wait := time.Duration(float64(elapsed)/b.config.Speed) - time.Since(began); wait > 0 {
+					time.Sleep(wait)
+				}
+			}
+			ticks <- t
+		}
+	}()
+	return ticks
+}
+
+// Start returns the simulated time of the first tick.
+func (b *Backfill) Start() time.Time {
+	return b.config.Start
+}
+
+func (b *Backfill) String() string {
+	return fmt.Sprintf("%s to %s", b.config.Start.Format(time.RFC3339), b.config.End.Format(time.RFC3339))
+}
@@ -0,0 +1,93 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backfill
+
+import (
+	"io/fs"
+	"strconv"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats/sysloglike"
+	"github.com/kube-logging/log-generator/formats/web"
+	"github.com/kube-logging/log-generator/log"
+)
+
+func TestTicks(t *testing.T) {
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	b, err := New(Config{Start: start, End: start.Add(time.Hour), Rate: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, last := 0, time.Time{}
+	for tick := range b.Ticks() {
+		if n == 0 && !tick.Equal(start) {
+			t.Errorf("Expected the first tick at %v, got %v", start, tick)
+		}
+		if !tick.After(last) || !tick.Before(start.Add(time.Hour)) {
+			t.Fatalf("Unexpected tick %v after %v", tick, last)
+		}
+		last = tick
+		n++
+	}
+	if n != 3600*100 {
+		t.Errorf("Expected %d ticks, got %d", 3600*100, n)
+	}
+
+	b, err = New(Config{Start: start, End: start.Add(time.Second), Rate: 10, Speed: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	began := time.Now()
+	for range b.Ticks() {
+	}
+	if d := time.Since(began); d < 80*time.Millisecond {
+		t.Errorf("Expected a second of simulated time to take 100ms at speed 10, took %v", d)
+	}
+
+	if _, err := New(Config{Start: start, End: start, Rate: 1}); err == nil {
+		t.Errorf("Expected an error of an empty window")
+	}
+}
+
+func TestSimulatedTime(t *testing.T) {
+	conf.Init()
+	at := time.Date(2026, 3, 1, 12, 30, 45, 123456789, time.UTC)
+
+	templates := fstest.MapFS{"stamps.tmpl": {Data: []byte(`{{.ISODateTime}} {{.BSDDateTime}} {{.UnixTime}} {{.UnixTimeMilli}} {{.UnixTimeNano}} {{.RFC3339NanoDateTime}}`)}}
+	l, err := sysloglike.NewRandomSyslog("stamps", &sync.Map{}, []fs.FS{templates})
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.SetTime(at)
+	msg, _ := l.String()
+	want := "2026-03-01T12:30:45Z Mar  1 12:30:45 1772368245 " + strconv.FormatInt(at.UnixMilli(), 10) + " " + strconv.FormatInt(at.UnixNano(), 10) + " 2026-03-01T12:30:45.123456789Z"
+	if msg != want {
+		t.Errorf("Expected %q, got %q", want, msg)
+	}
+
+	w, err := log.NewLogTemplate("envoy", web.TemplateFS, web.RandomData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.SetTime(at)
+	if msg, _ := w.String(); msg[:26] != "[2026-03-01T12:30:45.123Z]" {
+		t.Errorf("Expected the simulated time in %q", msg)
+	}
+}
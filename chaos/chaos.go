@@ -0,0 +1,179 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/log"
+	"github.com/kube-logging/log-generator/metrics"
+)
+
+// Config configures the corruption of events.
+type Config struct {
+	// Probability is the fraction of events that are corrupted.
+	Probability float64
+	// Corruptions lists "name=weight" entries, all corruptions are used with
+	// the same weight if empty.
+	Corruptions string
+	// LongTokenLength is the length of the tokens of the long_token corruption.
+	LongTokenLength int
+	// Seed makes the corrupted events and their content reproducible, a
+	// random seed is used if 0.
+	Seed int64
+}
+
+func ConfigFromViper() Config {
+	return Config{
+		Probability:     conf.Viper.GetFloat64("chaos.probability"),
+		Corruptions:     conf.Viper.GetString("chaos.corruptions"),
+		LongTokenLength: conf.Viper.GetInt("chaos.long_token_length"),
+		Seed:            conf.Viper.GetInt64("chaos.seed"),
+	}
+}
+
+// Names returns the names of the available corruptions.
+func Names() []string {
+	names := make([]string, 0, len(corruptions))
+	for name := range corruptions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Chaos corrupts a fraction of the events it wraps.
+type Chaos struct {
+	probability     float64
+	names           []string
+	weights         []int
+	total           int
+	longTokenLength int
+
+	m sync.Mutex
+	r *rand.Rand
+}
+
+// FromConfig returns the Chaos configured by the [chaos] config section, or
+// nil if it is disabled.
+func FromConfig() (*Chaos, error) {
+	if !conf.Viper.GetBool("chaos.enabled") {
+		return nil, nil
+	}
+	return New(ConfigFromViper())
+}
+
+func New(config Config) (*Chaos, error) {
+	if config.Probability < 0 || config.Probability > 1 {
+		return nil, fmt.Errorf("probability must be between 0 and 1, got %v", config.Probability)
+	}
+	if config.LongTokenLength <= 0 {
+		return nil, fmt.Errorf("long token length must be positive, got %d", config.LongTokenLength)
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	c := &Chaos{
+		probability:     config.Probability,
+		longTokenLength: config.LongTokenLength,
+		r:               rand.New(rand.NewSource(seed)),
+	}
+
+	spec := config.Corruptions
+	if strings.TrimSpace(spec) == "" {
+		spec = strings.Join(Names(), ",")
+	}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, weight := item, 1
+		if n, w, ok := strings.Cut(item, "="); ok {
+			var err error
+			if weight, err = strconv.Atoi(strings.TrimSpace(w)); err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid weight of corruption %q", n)
+			}
+			name = strings.TrimSpace(n)
+		}
+		if _, ok := corruptions[name]; !ok {
+			return nil, fmt.Errorf("unknown corruption %q", name)
+		}
+		c.names = append(c.names, name)
+		c.weights = append(c.weights, weight)
+		c.total += weight
+	}
+	if c.total == 0 {
+		return nil, fmt.Errorf("no corruptions with positive weight")
+	}
+
+	return c, nil
+}
+
+// Wrap returns l, or l corrupted by a random corruption for the configured
+// fraction of the events. A nil Chaos returns l unchanged.
+func (c *Chaos) Wrap(l log.Log) log.Log {
+	if c == nil || l == nil {
+		return l
+	}
+
+	c.m.Lock()
+	if c.r.Float64() >= c.probability {
+		c.m.Unlock()
+		return l
+	}
+	name := c.pick()
+	seed := c.r.Int63()
+	c.m.Unlock()
+
+	metrics.ChaosCorruptedEvents.With(map[string]string{"type": l.Labels()["type"], "corruption": name}).Inc()
+
+	return &corrupted{Log: l, corruption: name, seed: seed, longTokenLength: c.longTokenLength}
+}
+
+func (c *Chaos) pick() string {
+	n := c.r.Intn(c.total)
+	for i, w := range c.weights {
+		if n < w {
+			return c.names[i]
+		}
+		n -= w
+	}
+	return c.names[len(c.names)-1]
+}
+
+// corrupted is an event whose content is corrupted when it is rendered. The
+// corruption uses its own seed, so rendering it again gives the same result.
+type corrupted struct {
+	log.Log
+	corruption      string
+	seed            int64
+	longTokenLength int
+}
+
+func (c *corrupted) String() (string, float64) {
+	msg, _ := c.Log.String()
+	msg = corruptions[c.corruption](rand.New(rand.NewSource(c.seed)), msg, c.longTokenLength)
+	return msg, float64(len(msg))
+}
@@ -0,0 +1,57 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"testing"
+
+	"github.com/kube-logging/log-generator/log/logtest"
+)
+
+func TestCorruptions(t *testing.T) {
+	const msg = `2026-03-04T10:11:12Z level=info msg="request completed" {"status":200}`
+
+	for _, name := range Names() {
+		c, err := New(Config{Probability: 1, Corruptions: name, LongTokenLength: 64, Seed: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		l := c.Wrap(logtest.New(msg))
+		first, size := l.String()
+		if first == msg || size != float64(len(first)) {
+			t.Errorf("Event not corrupted, corruption=%q, %q", name, first)
+		}
+		if again, _ := l.String(); again != first {
+			t.Errorf("Corruption not reproducible, corruption=%q, %q != %q", name, again, first)
+		}
+	}
+}
+
+func TestChaosDisabled(t *testing.T) {
+	c, err := New(Config{Probability: 0, LongTokenLength: 64, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	event := logtest.New("msg")
+	if l := c.Wrap(event); l != event {
+		t.Errorf("Event wrapped with zero probability")
+	}
+
+	var disabled *Chaos
+	if l := disabled.Wrap(event); l != event {
+		t.Errorf("Event wrapped by disabled chaos")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+)
+
+// corruptions alter the rendered content of an event.
+var corruptions = map[string]func(r *rand.Rand, msg string, longTokenLength int) string{
+	"invalid_utf8":     invalidUTF8,
+	"nul":              nul,
+	"control":          control,
+	"truncate":         truncate,
+	"unbalanced_quote": unbalancedQuote,
+	"ansi":             ansi,
+	"line_endings":     lineEndings,
+	"long_token":       longToken,
+	"timestamp":        timestamp,
+}
+
+// insert inserts s at a random position of msg, on a byte boundary that may
+// split a multi-byte character.
+func insert(r *rand.Rand, msg, s string) string {
+	i := r.Intn(len(msg) + 1)
+	return msg[:i] + s + msg[i:]
+}
+
+// invalidUTF8Sequences are a stray continuation byte, bytes that never occur
+// in UTF-8, an overlong encoding, an encoded surrogate and a truncated
+// sequence.
+var invalidUTF8Sequences = []string{"\x80", "\xff\xfe", "\xc0\xaf", "\xed\xa0\x80", "\xe2\x82", "\xc3\x28"}
+
+func invalidUTF8(r *rand.Rand, msg string, _ int) string {
+	for n := 1 + r.Intn(3); n > 0; n-- {
+		msg = insert(r, msg, invalidUTF8Sequences[r.Intn(len(invalidUTF8Sequences))])
+	}
+	return msg
+}
+
+func nul(r *rand.Rand, msg string, _ int) string {
+	for n := 1 + r.Intn(3); n > 0; n-- {
+		msg = insert(r, msg, "\x00")
+	}
+	return msg
+}
+
+// controlCharacters are bell, backspace, vertical tab, form feed, escape,
+// delete and the C1 next line character.
+var controlCharacters = []string{"\x07", "\x08", "\x0b", "\x0c", "\x1b", "\x7f", "\u0085"}
+
+func control(r *rand.Rand, msg string, _ int) string {
+	for n := 1 + r.Intn(3); n > 0; n-- {
+		msg = insert(r, msg, controlCharacters[r.Intn(len(controlCharacters))])
+	}
+	return msg
+}
+
+// truncate cuts the event in its second half, which leaves JSON and XML
+// documents unterminated.
+func truncate(r *rand.Rand, msg string, _ int) string {
+	if len(msg) < 2 {
+		return ""
+	}
+	return msg[:len(msg)/2+r.Intn(len(msg)-len(msg)/2)]
+}
+
+func unbalancedQuote(r *rand.Rand, msg string, _ int) string {
+	// drop a closing quote if there is one, otherwise add an opening one
+	if i := strings.LastIndexAny(msg, `"'`); i >= 0 && r.Intn(2) == 0 {
+		return msg[:i] + msg[i+1:]
+	}
+	return insert(r, msg, []string{`"`, `'`, "`"}[r.Intn(3)])
+}
+
+var ansiColors = []string{"\x1b[31m", "\x1b[32m", "\x1b[33m", "\x1b[1;34m", "\x1b[35;1m", "\x1b[38;5;208m"}
+
+// ansi colors the words of the event as colored console output does.
+func ansi(r *rand.Rand, msg string, _ int) string {
+	words := strings.Split(msg, " ")
+	for n := 1 + r.Intn(3); n > 0; n-- {
+		i := r.Intn(len(words))
+		words[i] = ansiColors[r.Intn(len(ansiColors))] + words[i] + "\x1b[0m"
+	}
+	return strings.Join(words, " ")
+}
+
+// lineEndings breaks the event with a mix of CRLF, CR and LF line endings.
+func lineEndings(r *rand.Rand, msg string, _ int) string {
+	endings := []string{"\r\n", "\r", "\n"}
+	words := strings.Split(msg, " ")
+	for n := 1 + r.Intn(3); n > 0; n-- {
+		i := r.Intn(len(words))
+		words[i] += endings[r.Intn(len(endings))]
+	}
+	return strings.Join(words, " ") + endings[r.Intn(len(endings))]
+}
+
+func longToken(r *rand.Rand, msg string, length int) string {
+	const chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/"
+	token := make([]byte, length)
+	for i := range token {
+		token[i] = chars[r.Intn(len(chars))]
+	}
+
+	// replace a word, so the token is not separated from its neighbours
+	words := strings.Split(msg, " ")
+	words[r.Intn(len(words))] = string(token)
+	return strings.Join(words, " ")
+}
+
+// timestamps matches ISO 8601, Common Log Format and BSD syslog timestamps.
+var timestamps = regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?|\d{2}/[A-Z][a-z]{2}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}|[A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2}`)
+
+// wrongTimestamps are ambiguous (day and month order, two digit years, no
+// zone), out of range or in unexpected units.
+var wrongTimestamps = []string{
+	"03/04/2026 10:11:12",
+	"04.03.26 10:11",
+	"2026-13-45T25:61:61Z",
+	"2026-03-04 10:11:12 +25:00",
+	"2026-03-04T10:11:12",
+	"1772619072123456789",
+	"1772619072.5",
+	"Mar 4 10:11:12 PM",
+	"Wed, 04 Mar 2026 10:11:12 GMT",
+	"0000-00-00 00:00:00",
+	"yesterday",
+}
+
+// timestamp replaces the timestamp of the event with a wrong one, or prepends
+// one if there is none.
+func timestamp(r *rand.Rand, msg string, _ int) string {
+	wrong := wrongTimestamps[r.Intn(len(wrongTimestamps))]
+	if loc := timestamps.FindStringIndex(msg); loc != nil {
+		return msg[:loc[0]] + wrong + msg[loc[1]:]
+	}
+	return wrong + " " + msg
+}
@@ -16,6 +16,7 @@ package conf
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/go-viper/encoding/ini"
 	log "github.com/sirupsen/logrus"
@@ -46,7 +47,8 @@ func Init() {
 	}
 	log.SetLevel(level)
 
-	fmt.Printf("Using config: %s\n", Viper.ConfigFileUsed())
+	// stdout may be a compressed stream of events
+	fmt.Fprintf(os.Stderr, "Using config: %s\n", Viper.ConfigFileUsed())
 	Viper.SetDefault("message.count", 0)
 	Viper.SetDefault("message.randomise", true)
 	Viper.SetDefault("message.event-per-sec", 2)
@@ -67,6 +69,71 @@ func Init() {
 	Viper.SetDefault("golang.weight.info", 1)
 	Viper.SetDefault("golang.weight.warning", 0)
 	Viper.SetDefault("golang.weight.debug", 0)
+	Viper.SetDefault("web.clients", 500)
+	Viper.SetDefault("web.session_length", 8)
+	Viper.SetDefault("web.error_burst_probability", 0.002)
+	Viper.SetDefault("web.error_burst_length", 50)
+	Viper.SetDefault("web.latency_median", "40ms")
+	Viper.SetDefault("sysloglike.facilities", "kern=2,user=20,mail=3,daemon=25,auth=8,syslog=5,cron=6,authpriv=8,local0=10,local7=13")
+	Viper.SetDefault("sysloglike.severities", "crit=1,err=6,warning=12,notice=15,info=56,debug=10")
+	Viper.SetDefault("sysloglike.msgids", "-,ID47,AUDIT,LOGIN,LOGOUT,TCPIN,TCPOUT,CRON,HEALTH")
+	Viper.SetDefault("sysloglike.sd_elements", 2)
+	Viper.SetDefault("sysloglike.bom_probability", 0.1)
+	Viper.SetDefault("sysloglike.nil_probability", 0.05)
+	Viper.SetDefault("langpack.enabled", false)
+	Viper.SetDefault("langpack.ratio", 0.2)
+	Viper.SetDefault("tracing.enabled", false)
+	Viper.SetDefault("tracing.sample_ratio", 1.0)
+	Viper.SetDefault("tracing.requests", false)
+	Viper.SetDefault("tracing.format", "nginx")
+	Viper.SetDefault("tracing.app_lines", 3)
+	Viper.SetDefault("tracing.exception_probability", 0.05)
+	Viper.SetDefault("tracing.seed", 0)
+	Viper.SetDefault("topology.enabled", false)
+	Viper.SetDefault("topology.entry", "frontend")
+	Viper.SetDefault("topology.seed", 0)
+	Viper.SetDefault("timestamps.enabled", false)
+	Viper.SetDefault("timestamps.late_min", "1m")
+	Viper.SetDefault("timestamps.late_max", "2h")
+	Viper.SetDefault("timestamps.future_min", "1m")
+	Viper.SetDefault("timestamps.future_max", "1h")
+	Viper.SetDefault("backfill.enabled", false)
+	Viper.SetDefault("backfill.start", "168h")
+	Viper.SetDefault("backfill.rate", 100)
+	Viper.SetDefault("backfill.speed", 0)
+	Viper.SetDefault("replay.enabled", false)
+	Viper.SetDefault("exceptions.enabled", false)
+	Viper.SetDefault("structured.enabled", false)
+	Viper.SetDefault("structured.extra_fields", 3)
+	Viper.SetDefault("structured.nesting_depth", 1)
+	Viper.SetDefault("security.enabled", false)
+	Viper.SetDefault("database.enabled", false)
+	Viper.SetDefault("network.enabled", false)
+	Viper.SetDefault("network.csv_fields", "start,end,srcaddr,srcport,dstaddr,dstport,protocol,packets,bytes,action")
+	Viper.SetDefault("network.csv_delimiter", ",")
+	Viper.SetDefault("logfmt.enabled", false)
+	Viper.SetDefault("logfmt.fields", "time:time(rfc3339milli), level:enum(debug=10|info=70|warn=15|error=5), msg:words(2..6), method:enum(GET=6|POST=2|PUT|DELETE), path:enum(/api/v1/orders|/api/v1/users|/healthz|/metrics), status:enum(200=80|201=5|400=5|404=5|500=3|503=2), duration:duration(500us..5s), client:ip, request_id:uuid")
+	Viper.SetDefault("logfmt.severity_field", "level")
+
+	Viper.SetDefault("chaos.enabled", false)
+	Viper.SetDefault("chaos.probability", 0.01)
+	Viper.SetDefault("chaos.long_token_length", 16384)
+	Viper.SetDefault("chaos.seed", 0)
+
+	Viper.SetDefault("pii.enabled", false)
+	Viper.SetDefault("pii.rate", 0.05)
+	Viper.SetDefault("pii.per_event", 1)
+	Viper.SetDefault("pii.seed", 0)
+
+	Viper.SetDefault("padding.enabled", false)
+	Viper.SetDefault("padding.distribution", "lognormal")
+	Viper.SetDefault("padding.size", "1KiB")
+	Viper.SetDefault("padding.stddev", "256")
+	Viper.SetDefault("padding.sigma", 1.0)
+	Viper.SetDefault("padding.min", "0")
+	Viper.SetDefault("padding.max", "16MiB")
+	Viper.SetDefault("padding.large_sizes", "16KiB,64KiB,1MiB,4MiB")
+	Viper.SetDefault("padding.seed", 0)
 
 	Viper.SetDefault("destination.file.create", true)
 	Viper.SetDefault("destination.file.append", true)
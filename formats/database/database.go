@@ -0,0 +1,142 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kube-logging/log-generator/log"
+)
+
+// generators create the content of the events of a format, and renderers
+// format it when the event is emitted.
+var generators = map[string]func(r *rand.Rand) event{
+	"postgresql":      newPostgresEvent,
+	"postgresql.csv":  newPostgresEvent,
+	"postgresql.json": newPostgresEvent,
+	"mysql":           newMySQLEvent,
+	"mysql.slow":      newMySQLSlowEvent,
+	"mongodb":         newMongoEvent,
+	"redis":           newRedisEvent,
+}
+
+var renderers = map[string]func(e event, t time.Time) string{
+	"postgresql":      renderPostgresStderr,
+	"postgresql.csv":  renderPostgresCSV,
+	"postgresql.json": renderPostgresJSON,
+	"mysql":           renderMySQL,
+	"mysql.slow":      renderMySQLSlow,
+	"mongodb":         renderMongo,
+	"redis":           renderRedis,
+}
+
+func Formats() []string {
+	formats := make([]string, 0, len(generators))
+	for f := range generators {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+type event interface {
+	severity() string
+}
+
+type Database struct {
+	Format string
+
+	event    event
+	time     time.Time
+	isFramed bool
+}
+
+// NewDatabase returns a log entry of a database server, which may span
+// multiple lines. Without randomise, the same event is returned every time.
+func NewDatabase(format string, randomise bool) (*Database, error) {
+	generate, ok := generators[format]
+	if !ok {
+		return nil, fmt.Errorf("could not find format %q", format)
+	}
+
+	seed := int64(1)
+	if randomise {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Database{
+		Format: format,
+		event:  generate(rand.New(rand.NewSource(seed))),
+	}, nil
+}
+
+func (d *Database) String() (string, float64) {
+	msg := renderers[d.Format](d.event, log.TimeOrNow(d.time))
+	return msg, float64(len(msg))
+}
+
+func (d *Database) Timestamp() time.Time {
+	return d.time
+}
+
+func (d *Database) SetTime(t time.Time) {
+	d.time = t
+}
+
+func (d *Database) IsFramed() bool {
+	return d.isFramed
+}
+
+func (d *Database) SetFramed(f bool) {
+	d.isFramed = f
+}
+
+func (d *Database) Labels() prometheus.Labels {
+	return prometheus.Labels{
+		"type":     "database." + d.Format,
+		"severity": d.event.severity(),
+	}
+}
+
+func pick(r *rand.Rand, items ...string) string {
+	return items[r.Intn(len(items))]
+}
+
+var (
+	databases = []string{"shop", "inventory", "billing", "auth"}
+	users     = []string{"app", "app", "reporting", "migrator", "postgres"}
+	clients   = []string{"psql", "orders-api", "PostgreSQL JDBC Driver", "pgbouncer", ""}
+	tables    = []string{"orders", "customers", "order_items", "payments", "accounts"}
+)
+
+func clientAddress(r *rand.Rand) string {
+	return fmt.Sprintf("10.42.%d.%d", r.Intn(16), 1+r.Intn(254))
+}
+
+// statements are SQL statements as sent by clients, most of them spanning
+// multiple lines.
+var statements = []string{
+	"SELECT o.id, o.created_at, c.email\nFROM orders o\nJOIN customers c ON c.id = o.customer_id\nWHERE o.status = 'pending'\n  AND o.created_at > now() - interval '1 day'\nORDER BY o.created_at DESC\nLIMIT 50;",
+	"UPDATE accounts\n   SET balance = balance - 25.00,\n       updated_at = now()\n WHERE id = 1042;",
+	"INSERT INTO customers (email, name, created_at)\nVALUES ('jane.doe@example.com', 'Jane O''Doe', now())\nRETURNING id;",
+	"select count(*) from order_items where order_id in (select id from orders where customer_id = 77)",
+	"WITH recent AS (\n    SELECT customer_id, sum(total) AS spent\n      FROM orders\n     WHERE created_at > now() - interval '30 days'\n     GROUP BY customer_id\n)\nSELECT c.email, r.spent\n  FROM recent r\n  JOIN customers c ON c.id = r.customer_id\n ORDER BY r.spent DESC;",
+	"DELETE FROM sessions WHERE expires_at < now();",
+}
@@ -0,0 +1,101 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/kube-logging/log-generator/log"
+)
+
+// mongoEvent is a MongoDB structured log message. The attributes are kept as
+// raw JSON because the server writes command documents in field order.
+type mongoEvent struct {
+	s         string
+	component string
+	id        int
+	ctx       string
+	msg       string
+	attr      string
+}
+
+func (e mongoEvent) severity() string {
+	switch e.s {
+	case "W":
+		return "warning"
+	case "E":
+		return "error"
+	case "F":
+		return "fatal"
+	default:
+		return "info"
+	}
+}
+
+func newMongoEvent(r *rand.Rand) event {
+	conn := 1 + r.Intn(5000)
+	remote := fmt.Sprintf("%s:%d", clientAddress(r), 32768+r.Intn(28232))
+	db, collection := pick(r, databases...), pick(r, tables...)
+	e := mongoEvent{s: "I", ctx: fmt.Sprintf("conn%d", conn)}
+
+	switch n := r.Intn(100); {
+	case n < 25:
+		e.component, e.id, e.ctx, e.msg = "NETWORK", 22943, "listener", "Connection accepted"
+		e.attr = fmt.Sprintf(`{"remote":%q,"isLoadBalanced":false,"uuid":{"uuid":{"$uuid":"%08x-%04x-4%03x-%04x-%012x"}},"connectionId":%d,"connectionCount":%d}`,
+			remote, r.Uint32(), r.Intn(1<<16), r.Intn(1<<12), 0x8000|r.Intn(1<<14), r.Int63n(1<<48), conn, 1+r.Intn(200))
+	case n < 45:
+		e.component, e.id, e.msg = "NETWORK", 22944, "Connection ended"
+		e.attr = fmt.Sprintf(`{"remote":%q,"isLoadBalanced":false,"connectionId":%d,"connectionCount":%d}`, remote, conn, r.Intn(200))
+	case n < 70:
+		e.component, e.id, e.msg = "COMMAND", 51803, "Slow query"
+		e.attr = fmt.Sprintf(`{"type":"command","ns":"%s.%s","command":{"find":%q,"filter":{"status":"pending","createdAt":{"$gt":{"$date":"2026-01-01T00:00:00.000Z"}}},"sort":{"createdAt":-1},"limit":50,"lsid":{"id":{"$uuid":"%08x-%04x-4%03x-%04x-%012x"}},"$db":%q},"planSummary":"%s","keysExamined":%d,"docsExamined":%d,"cursorExhausted":true,"numYields":%d,"nreturned":%d,"queryHash":"%08X","reslen":%d,"locks":{"Global":{"acquireCount":{"r":%d}}},"storage":{"data":{"bytesRead":%d,"timeReadingMicros":%d}},"protocol":"op_msg","durationMillis":%d}`,
+			db, collection, collection, r.Uint32(), r.Intn(1<<16), r.Intn(1<<12), 0x8000|r.Intn(1<<14), r.Int63n(1<<48), db,
+			pick(r, "COLLSCAN", "IXSCAN { status: 1, createdAt: -1 }"), r.Intn(1000), r.Intn(1000000), r.Intn(2000), r.Intn(50), r.Uint32(), r.Intn(100000),
+			1+r.Intn(2000), r.Intn(100000000), r.Intn(1000000), 100+int(r.ExpFloat64()*500))
+	case n < 80:
+		e.component, e.id, e.msg = "ACCESS", 20249, "Authentication failed"
+		e.attr = fmt.Sprintf(`{"mechanism":"SCRAM-SHA-256","speculative":false,"principalName":%q,"authenticationDatabase":"admin","remote":%q,"extraInfo":{},"error":"AuthenticationFailed: SCRAM authentication failed, storedKey mismatch"}`,
+			pick(r, users...), remote)
+	case n < 90:
+		e.component, e.id, e.ctx, e.msg = "WTCHKPT", 22430, "Checkpointer", "WiredTiger message"
+		e.attr = fmt.Sprintf(`{"message":{"ts_sec":%d,"ts_usec":%d,"thread":"1:0x7f2c%08x","session_name":"WT_SESSION.checkpoint","category":"WT_VERB_CHECKPOINT_PROGRESS","category_id":6,"verbose_level":"DEBUG_1","verbose_level_id":1,"msg":"saving checkpoint snapshot min: %d, snapshot max: %d snapshot count: 0, oldest timestamp: (0, 0) , meta checkpoint timestamp: (0, 0) base write gen: %d"}}`,
+			log.Now().Unix(), r.Intn(1000000), r.Uint32(), r.Intn(100000), r.Intn(100000), r.Intn(10000000))
+	case n < 97:
+		e.s, e.component, e.id, e.msg = "W", "QUERY", 23798, "Plan executor error during find command"
+		e.attr = fmt.Sprintf(`{"error":{"code":292,"codeName":"QueryExceededMemoryLimitNoDiskUseAllowed","errmsg":"Sort exceeded memory limit of 104857600 bytes, but did not opt in to external sorting."},"stats":{"stage":"SORT","nReturned":0,"works":%d},"cmd":{"find":%q,"filter":{},"sort":{"total":-1},"$db":%q}}`,
+			r.Intn(1000000), collection, db)
+	default:
+		e.s, e.component, e.id, e.ctx, e.msg = "E", "REPL", 21799, "ReplCoord-1", "Sync source candidate chosen was not viable"
+		e.attr = fmt.Sprintf(`{"syncSource":"mongodb-%d.mongodb.%s.svc.cluster.local:27017","error":{"code":6,"codeName":"HostUnreachable","errmsg":"Error connecting to mongodb-%d.mongodb.%s.svc.cluster.local:27017 :: caused by :: Connection refused"}}`,
+			r.Intn(3), db, r.Intn(3), db)
+	}
+
+	return e
+}
+
+func renderMongo(ev event, t time.Time) string {
+	e := ev.(mongoEvent)
+	date, _ := json.Marshal(t.Format("2006-01-02T15:04:05.000-07:00"))
+	msg, _ := json.Marshal(e.msg)
+
+	// mongod aligns the fields after the component, never inside its value
+	pad := strings.Repeat(" ", max(0, 8-len(e.component)))
+	return fmt.Sprintf(`{"t":{"$date":%s},"s":"%s","c":%q,%s"id":%d,"ctx":%q,"msg":%s,"attr":%s}`,
+		date, e.s, e.component, pad, e.id, e.ctx, msg, e.attr)
+}
@@ -0,0 +1,129 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// mysqlEvent is a message of the MySQL 8 error log.
+type mysqlEvent struct {
+	thread    int
+	priority  string
+	code      string
+	subsystem string
+	message   string
+}
+
+func (e mysqlEvent) severity() string {
+	return strings.ToLower(e.priority)
+}
+
+func newMySQLEvent(r *rand.Rand) event {
+	e := mysqlEvent{thread: r.Intn(5000), priority: "Note", subsystem: "Server"}
+	host, user, db := clientAddress(r), pick(r, users...), pick(r, databases...)
+
+	switch n := r.Intn(100); {
+	case n < 30:
+		e.priority, e.code = "Warning", "MY-010055"
+		e.message = fmt.Sprintf("IP address '%s' could not be resolved: Name or service not known", host)
+	case n < 55:
+		e.code = "MY-010914"
+		e.message = fmt.Sprintf("Aborted connection %d to db: '%s' user: '%s' host: '%s' (Got an error reading communication packets).", e.thread, db, user, host)
+	case n < 70:
+		e.priority, e.code = "Warning", "MY-013360"
+		e.message = "Plugin mysql_native_password reported: ''mysql_native_password' is deprecated and will be removed in a future release. Please use caching_sha2_password instead'"
+	case n < 80:
+		e.priority, e.code = "ERROR", "MY-012592"
+		e.subsystem = "InnoDB"
+		e.message = "Operating system error number 28 in a file operation."
+	case n < 88:
+		e.thread, e.priority, e.code = 0, "System", "MY-010931"
+		e.message = "/usr/sbin/mysqld: ready for connections. Version: '8.0.36'  socket: '/var/run/mysqld/mysqld.sock'  port: 3306  MySQL Community Server - GPL."
+	default:
+		// with innodb_print_all_deadlocks the lock dump follows the note
+		t1, t2 := 400000+r.Intn(100000), 400000+r.Intn(100000)
+		e.thread, e.code = 0, "MY-012468"
+		e.subsystem = "InnoDB"
+		e.message = fmt.Sprintf("Transactions deadlock detected, dumping detailed information. (lock0lock.cc:6482)\n"+
+			"*** (1) TRANSACTION:\n"+
+			"TRANSACTION %d, ACTIVE 0 sec starting index read\n"+
+			"mysql tables in use 1, locked 1\n"+
+			"LOCK WAIT 2 lock struct(s), heap size 1128, 1 row lock(s)\n"+
+			"MySQL thread id %d, OS thread handle 140223361005312, query id %d %s %s updating\n"+
+			"UPDATE accounts SET balance = balance - 25.00 WHERE id = 1042\n\n"+
+			"*** (2) TRANSACTION:\n"+
+			"TRANSACTION %d, ACTIVE 0 sec starting index read\n"+
+			"mysql tables in use 1, locked 1\n"+
+			"3 lock struct(s), heap size 1128, 2 row lock(s)\n"+
+			"MySQL thread id %d, OS thread handle 140223359891200, query id %d %s %s updating\n"+
+			"UPDATE accounts SET balance = balance + 25.00 WHERE id = 1042\n\n"+
+			"*** WE ROLL BACK TRANSACTION (2)",
+			t1, r.Intn(5000), r.Intn(100000), host, user, t2, r.Intn(5000), r.Intn(100000), clientAddress(r), user)
+	}
+
+	return e
+}
+
+func renderMySQL(ev event, t time.Time) string {
+	e := ev.(mysqlEvent)
+	return fmt.Sprintf("%s %d [%s] [%s] [%s] %s", t.UTC().Format("2006-01-02T15:04:05.000000Z"), e.thread, e.priority, e.code, e.subsystem, e.message)
+}
+
+// mysqlSlowEvent is an entry of the slow query log.
+type mysqlSlowEvent struct {
+	user         string
+	host         string
+	id           int
+	database     string
+	queryTime    float64
+	lockTime     float64
+	rowsSent     int
+	rowsExamined int
+	statement    string
+}
+
+func (e mysqlSlowEvent) severity() string {
+	return "slow"
+}
+
+func newMySQLSlowEvent(r *rand.Rand) event {
+	return mysqlSlowEvent{
+		user:         pick(r, users...),
+		host:         clientAddress(r),
+		id:           r.Intn(5000),
+		database:     pick(r, databases...),
+		queryTime:    1 + r.ExpFloat64()*3,
+		lockTime:     r.Float64() / 1000,
+		rowsSent:     r.Intn(500),
+		rowsExamined: r.Intn(5000000),
+		statement:    statements[r.Intn(len(statements))],
+	}
+}
+
+func renderMySQLSlow(ev event, t time.Time) string {
+	e := ev.(mysqlSlowEvent)
+	statement := e.statement
+	if !strings.HasSuffix(statement, ";") {
+		statement += ";"
+	}
+
+	return fmt.Sprintf("# Time: %s\n# User@Host: %s[%s] @  [%s]  Id: %5d\n# Query_time: %f  Lock_time: %f Rows_sent: %d  Rows_examined: %d\nuse %s;\nSET timestamp=%d;\n%s",
+		t.UTC().Format("2006-01-02T15:04:05.000000Z"), e.user, e.user, e.host, e.id,
+		e.queryTime, e.lockTime, e.rowsSent, e.rowsExamined, e.database, t.Unix(), statement)
+}
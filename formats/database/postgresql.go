@@ -0,0 +1,264 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// postgresEvent is a server log message with its optional secondary fields.
+type postgresEvent struct {
+	level      string
+	sqlState   string
+	message    string
+	detail     string
+	hint       string
+	context    string
+	statement  string
+	commandTag string
+
+	pid          int
+	user         string
+	database     string
+	application  string
+	host         string
+	port         int
+	sessionStart time.Duration
+	lineNum      int
+	vxid         string
+	txid         int
+	backendType  string
+}
+
+func (e postgresEvent) severity() string {
+	return strings.ToLower(e.level)
+}
+
+func newPostgresEvent(r *rand.Rand) event {
+	table := pick(r, tables...)
+	statement := statements[r.Intn(len(statements))]
+
+	e := postgresEvent{
+		level:        "LOG",
+		sqlState:     "00000",
+		pid:          100 + r.Intn(60000),
+		user:         pick(r, users...),
+		database:     pick(r, databases...),
+		application:  pick(r, clients...),
+		host:         clientAddress(r),
+		port:         32768 + r.Intn(28232),
+		sessionStart: time.Duration(r.Intn(3600)) * time.Second,
+		lineNum:      1 + r.Intn(200),
+		vxid:         fmt.Sprintf("%d/%d", 3+r.Intn(60), r.Intn(100000)),
+		backendType:  "client backend",
+	}
+
+	switch n := r.Intn(100); {
+	case n < 30:
+		e.message = fmt.Sprintf("duration: %.3f ms  statement: %s", 500+r.ExpFloat64()*2000, statement)
+		e.commandTag = strings.ToUpper(strings.Fields(statement)[0])
+	case n < 45:
+		e.level, e.sqlState, e.commandTag = "ERROR", "23505", "INSERT"
+		e.message = `duplicate key value violates unique constraint "customers_email_key"`
+		e.detail = "Key (email)=(jane.doe@example.com) already exists."
+		e.statement = statements[2]
+		e.txid = 700000 + r.Intn(100000)
+	case n < 55:
+		e.level, e.sqlState, e.commandTag = "ERROR", "42P01", "SELECT"
+		e.message = fmt.Sprintf(`relation "%ss" does not exist at character 15`, table)
+		e.statement = fmt.Sprintf("select * from %ss where id = $1", table)
+	case n < 63:
+		e.level, e.sqlState, e.commandTag = "ERROR", "40P01", "UPDATE"
+		p2 := 100 + r.Intn(60000)
+		t1, t2 := 700000+r.Intn(100000), 700000+r.Intn(100000)
+		e.message = "deadlock detected"
+		e.detail = fmt.Sprintf("Process %d waits for ShareLock on transaction %d; blocked by process %d.\n"+
+			"Process %d waits for ShareLock on transaction %d; blocked by process %d.\n"+
+			"Process %d: %s\nProcess %d: UPDATE accounts SET balance = balance + 25.00 WHERE id = 1042;",
+			e.pid, t2, p2, p2, t1, e.pid, e.pid, statements[1], p2)
+		e.hint = "See server log for query details."
+		e.context = "while updating tuple (0,5) in relation \"accounts\""
+		e.statement = statements[1]
+		e.txid = t1
+	case n < 70:
+		e.level, e.sqlState, e.commandTag = "FATAL", "28P01", "authentication"
+		e.message = fmt.Sprintf(`password authentication failed for user "%s"`, e.user)
+		e.detail = `Connection matched file "/var/lib/postgresql/data/pg_hba.conf" line 100: "host all all all scram-sha-256"`
+	case n < 75:
+		e.level, e.sqlState, e.commandTag = "ERROR", "57014", "SELECT"
+		e.message = "canceling statement due to statement timeout"
+		e.statement = statements[4]
+	case n < 80:
+		e.level, e.sqlState, e.commandTag = "WARNING", "25P01", "COMMIT"
+		e.message = "there is no transaction in progress"
+	case n < 90:
+		e.commandTag = "authentication"
+		e.message = fmt.Sprintf("connection authorized: user=%s database=%s application_name=%s SSL enabled (protocol=TLSv1.3, cipher=TLS_AES_256_GCM_SHA384, bits=256)", e.user, e.database, e.application)
+	case n < 95:
+		e.user, e.database, e.application, e.host, e.port, e.vxid = "", "", "", "", 0, ""
+		e.backendType = "checkpointer"
+		e.message = fmt.Sprintf("checkpoint complete: wrote %d buffers (%.1f%%); 0 WAL file(s) added, 0 removed, %d recycled; write=%.3f s, sync=%.3f s, total=%.3f s; sync files=%d, longest=%.3f s, average=%.3f s; distance=%d kB, estimate=%d kB; lsn=%X/%X, redo lsn=%X/%X",
+			r.Intn(5000), r.Float64()*10, r.Intn(4), r.Float64()*270, r.Float64(), 270+r.Float64()*10, r.Intn(200), r.Float64()/10, r.Float64()/100, r.Intn(100000), r.Intn(100000), r.Intn(16), r.Uint32(), r.Intn(16), r.Uint32())
+	default:
+		e.user, e.database, e.application, e.host, e.port, e.vxid = "", "", "", "", 0, ""
+		e.backendType = "autovacuum worker"
+		e.message = fmt.Sprintf("automatic vacuum of table \"%s.public.%s\": index scans: 1\n"+
+			"pages: 0 removed, %d remain, %d scanned (100.00%% of total)\n"+
+			"tuples: %d removed, %d remain, 0 are dead but not yet removable\n"+
+			"index scan needed: %d pages from table (%.2f%% of total) had %d dead item identifiers removed\n"+
+			"avg read rate: %.3f MB/s, avg write rate: %.3f MB/s\n"+
+			"buffer usage: %d hits, %d misses, %d dirtied\n"+
+			"WAL usage: %d records, %d full page images, %d bytes\n"+
+			"system usage: CPU: user: 0.%02d s, system: 0.00 s, elapsed: 0.%02d s",
+			pick(r, databases...), table, 100+r.Intn(10000), 100+r.Intn(10000), r.Intn(5000), r.Intn(1000000),
+			r.Intn(100), r.Float64()*10, r.Intn(5000), r.Float64()*50, r.Float64()*50,
+			r.Intn(10000), r.Intn(100), r.Intn(100), r.Intn(10000), r.Intn(100), r.Intn(1000000), r.Intn(100), r.Intn(100))
+	}
+
+	return e
+}
+
+func (e postgresEvent) timestamp(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05.000 MST")
+}
+
+func (e postgresEvent) sessionID(t time.Time) string {
+	return fmt.Sprintf("%x.%x", t.Add(-e.sessionStart).Unix(), e.pid)
+}
+
+// withTabs indents continuation lines with a tab, as the server does for
+// messages written to stderr.
+func withTabs(s string) string {
+	return strings.ReplaceAll(s, "\n", "\n\t")
+}
+
+// renderPostgresStderr renders the event with log_line_prefix = '%m [%p] %q%u@%d '.
+func renderPostgresStderr(ev event, t time.Time) string {
+	e := ev.(postgresEvent)
+
+	prefix := fmt.Sprintf("%s [%d] ", e.timestamp(t), e.pid)
+	if e.user != "" {
+		prefix += e.user + "@" + e.database + " "
+	}
+
+	var b strings.Builder
+	line := func(label, msg string) {
+		if msg == "" {
+			return
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(prefix)
+		b.WriteString(label)
+		b.WriteString(":  ")
+		b.WriteString(withTabs(msg))
+	}
+
+	line(e.level, e.message)
+	line("DETAIL", e.detail)
+	line("HINT", e.hint)
+	line("CONTEXT", e.context)
+	line("STATEMENT", e.statement)
+
+	return b.String()
+}
+
+// renderPostgresCSV renders the event as a csvlog line. Fields with line
+// breaks are quoted, so a record may span multiple lines.
+func renderPostgresCSV(ev event, t time.Time) string {
+	e := ev.(postgresEvent)
+
+	from := ""
+	if e.host != "" {
+		from = e.host + ":" + strconv.Itoa(e.port)
+	}
+
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	_ = w.Write([]string{
+		e.timestamp(t), e.user, e.database, strconv.Itoa(e.pid), from, e.sessionID(t), strconv.Itoa(e.lineNum),
+		e.commandTag, t.Add(-e.sessionStart).UTC().Format("2006-01-02 15:04:05 MST"), e.vxid, strconv.Itoa(e.txid),
+		e.level, e.sqlState, e.message, e.detail, e.hint, "", "", e.context, e.statement, "", "",
+		e.application, e.backendType, "", "0",
+	})
+	w.Flush()
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+type postgresJSON struct {
+	Timestamp       string `json:"timestamp"`
+	User            string `json:"user,omitempty"`
+	DBName          string `json:"dbname,omitempty"`
+	PID             int    `json:"pid"`
+	RemoteHost      string `json:"remote_host,omitempty"`
+	RemotePort      int    `json:"remote_port,omitempty"`
+	SessionID       string `json:"session_id"`
+	LineNum         int    `json:"line_num"`
+	PS              string `json:"ps,omitempty"`
+	SessionStart    string `json:"session_start"`
+	VXID            string `json:"vxid,omitempty"`
+	TXID            int    `json:"txid,omitempty"`
+	ErrorSeverity   string `json:"error_severity"`
+	StateCode       string `json:"state_code,omitempty"`
+	Message         string `json:"message"`
+	Detail          string `json:"detail,omitempty"`
+	Hint            string `json:"hint,omitempty"`
+	Context         string `json:"context,omitempty"`
+	Statement       string `json:"statement,omitempty"`
+	ApplicationName string `json:"application_name,omitempty"`
+	BackendType     string `json:"backend_type"`
+	QueryID         int    `json:"query_id"`
+}
+
+// renderPostgresJSON renders the event as a jsonlog line.
+func renderPostgresJSON(ev event, t time.Time) string {
+	e := ev.(postgresEvent)
+
+	out, err := json.Marshal(postgresJSON{
+		Timestamp:       e.timestamp(t),
+		User:            e.user,
+		DBName:          e.database,
+		PID:             e.pid,
+		RemoteHost:      e.host,
+		RemotePort:      e.port,
+		SessionID:       e.sessionID(t),
+		LineNum:         e.lineNum,
+		PS:              e.commandTag,
+		SessionStart:    t.Add(-e.sessionStart).UTC().Format("2006-01-02 15:04:05 MST"),
+		VXID:            e.vxid,
+		TXID:            e.txid,
+		ErrorSeverity:   e.level,
+		StateCode:       e.sqlState,
+		Message:         e.message,
+		Detail:          e.detail,
+		Hint:            e.hint,
+		Context:         e.context,
+		Statement:       e.statement,
+		ApplicationName: e.application,
+		BackendType:     e.backendType,
+	})
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
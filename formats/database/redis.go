@@ -0,0 +1,95 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// redisEvent is a line of the Redis server log.
+type redisEvent struct {
+	pid int
+	// role is X for sentinel, C for child processes, S for replicas and M
+	// for masters
+	role  byte
+	level byte
+	msg   string
+}
+
+func (e redisEvent) severity() string {
+	switch e.level {
+	case '.':
+		return "debug"
+	case '-':
+		return "verbose"
+	case '#':
+		return "warning"
+	default:
+		return "notice"
+	}
+}
+
+func newRedisEvent(r *rand.Rand) event {
+	e := redisEvent{pid: 1, role: 'M', level: '*'}
+	replica := fmt.Sprintf("%s:6379", clientAddress(r))
+
+	switch n := r.Intn(100); {
+	case n < 20:
+		e.msg = fmt.Sprintf("%d changes in %d seconds. Saving...", 1+r.Intn(10000), pickInt(r, 60, 300, 3600))
+	case n < 35:
+		e.msg = fmt.Sprintf("Background saving started by pid %d", 100+r.Intn(30000))
+	case n < 50:
+		e.pid, e.role = 100+r.Intn(30000), 'C'
+		if r.Intn(2) == 0 {
+			e.msg = "DB saved on disk"
+		} else {
+			e.msg = fmt.Sprintf("Fork CoW for RDB: current %d MB, peak %d MB, average %d MB", r.Intn(10), 1+r.Intn(20), r.Intn(10))
+		}
+	case n < 62:
+		e.msg = "Background saving terminated with success"
+	case n < 72:
+		e.level = '-'
+		e.msg = fmt.Sprintf("Accepted %s:%d", clientAddress(r), 32768+r.Intn(28232))
+	case n < 80:
+		e.role = 'S'
+		e.msg = "MASTER <-> REPLICA sync: Finished with success"
+	case n < 87:
+		e.level = '#'
+		e.msg = fmt.Sprintf("Connection with replica %s lost.", replica)
+	case n < 93:
+		e.level = '#'
+		e.msg = fmt.Sprintf("Client id=%d addr=%s:%d laddr=10.42.0.9:6379 fd=%d name= age=%d idle=0 flags=N db=0 sub=0 psub=0 ssub=0 multi=-1 watch=0 qbuf=0 qbuf-free=0 argv-mem=0 multi-mem=0 rbs=1024 rbp=0 obl=0 oll=%d omem=%d tot-mem=%d events=rw cmd=psubscribe user=default redir=-1 resp=2 lib-name= lib-ver= closed for overcoming of output buffer limits.",
+			r.Intn(100000), clientAddress(r), 32768+r.Intn(28232), 8+r.Intn(1000), r.Intn(10000), 1000+r.Intn(10000), 33554432+r.Intn(1000000), 33554432+r.Intn(1000000))
+	case n < 97:
+		e.level = '#'
+		e.msg = "WARNING Memory overcommit must be enabled! Without it, a background save or replication may fail under low memory condition. Being disabled, it can also cause failures without low memory condition, see https://github.com/jemalloc/jemalloc/issues/1328. To fix this issue add 'vm.overcommit_memory = 1' to /etc/sysctl.conf and then reboot or run the command 'sysctl vm.overcommit_memory=1' for this to take effect."
+	default:
+		e.role = 'X'
+		e.msg = fmt.Sprintf("+sdown master mymaster %s 6379", replica[:len(replica)-5])
+	}
+
+	return e
+}
+
+func pickInt(r *rand.Rand, items ...int) int {
+	return items[r.Intn(len(items))]
+}
+
+func renderRedis(ev event, t time.Time) string {
+	e := ev.(redisEvent)
+	return fmt.Sprintf("%d:%c %s %c %s", e.pid, e.role, t.UTC().Format("02 Jan 2006 15:04:05.000"), e.level, e.msg)
+}
@@ -0,0 +1,341 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package exceptions
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// generators render a multi-line stack trace in the format of a language.
+var generators = map[string]func(r *rand.Rand) string{
+	"java":   javaTrace,
+	"python": pythonTrace,
+	"go":     goTrace,
+	"ruby":   rubyTrace,
+	"node":   nodeTrace,
+	"dotnet": dotnetTrace,
+}
+
+func Formats() []string {
+	formats := make([]string, 0, len(generators))
+	for f := range generators {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+type Exception struct {
+	Format string
+
+	trace    string
+	isFramed bool
+}
+
+// NewException returns a stack trace in the given format. Without randomise,
+// the same trace is returned every time.
+func NewException(format string, randomise bool) (*Exception, error) {
+	generate, ok := generators[format]
+	if !ok {
+		return nil, fmt.Errorf("could not find format %q", format)
+	}
+
+	seed := int64(1)
+	if randomise {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Exception{
+		Format: format,
+		trace:  generate(rand.New(rand.NewSource(seed))),
+	}, nil
+}
+
+func (e *Exception) String() (string, float64) {
+	return e.trace, float64(len(e.trace))
+}
+
+func (e *Exception) IsFramed() bool {
+	return e.isFramed
+}
+
+func (e *Exception) SetFramed(f bool) {
+	e.isFramed = f
+}
+
+func (e *Exception) Labels() prometheus.Labels {
+	return prometheus.Labels{
+		"type":     "exceptions." + e.Format,
+		"severity": "error",
+	}
+}
+
+func pick(r *rand.Rand, items ...string) string {
+	return items[r.Intn(len(items))]
+}
+
+// fill replaces the verb of a message template with a random number.
+func fill(r *rand.Rand, template string) string {
+	if !strings.Contains(template, "%") {
+		return template
+	}
+	return fmt.Sprintf(template, r.Intn(10000))
+}
+
+// depth returns the number of frames of a trace, between min and max.
+func depth(r *rand.Rand, min, max int) int {
+	return min + r.Intn(max-min+1)
+}
+
+var (
+	javaPackages = []string{"com.example.shop.orders", "com.example.shop.payment", "com.example.shop.inventory", "org.acme.billing", "io.acme.gateway.routing"}
+	javaClasses  = []string{"OrderService", "PaymentController", "InventoryRepository", "InvoiceGenerator", "RequestDispatcher", "CustomerMapper"}
+	javaMethods  = []string{"process", "handle", "findById", "validate", "lambda$submit$0", "doFilter", "apply", "execute"}
+	javaLibrary  = []string{
+		"org.springframework.web.servlet.FrameworkServlet.service(FrameworkServlet.java:883)",
+		"org.springframework.web.servlet.DispatcherServlet.doDispatch(DispatcherServlet.java:1067)",
+		"org.apache.catalina.core.ApplicationFilterChain.internalDoFilter(ApplicationFilterChain.java:166)",
+		"org.hibernate.internal.SessionImpl.find(SessionImpl.java:2425)",
+		"java.base/java.util.concurrent.ThreadPoolExecutor.runWorker(ThreadPoolExecutor.java:1136)",
+		"java.base/java.util.concurrent.ThreadPoolExecutor$Worker.run(ThreadPoolExecutor.java:635)",
+		"java.base/java.lang.Thread.run(Thread.java:840)",
+	}
+	javaExceptions = []string{
+		"java.lang.IllegalStateException: Order %d is already being processed",
+		"java.lang.NullPointerException: Cannot invoke \"com.example.shop.orders.Order.getId()\" because \"order\" is null",
+		"java.lang.IllegalArgumentException: Invalid quantity: -%d",
+		"org.springframework.dao.DataIntegrityViolationException: could not execute statement; constraint [orders_pkey %d]",
+		"java.util.concurrent.TimeoutException: Timed out after %dms waiting for payment",
+	}
+	javaCauses = []string{
+		"java.io.IOException: Connection reset by peer",
+		"java.net.SocketTimeoutException: Read timed out",
+		"java.sql.SQLException: Connection is not available, request timed out after 30000ms.",
+		"java.lang.NumberFormatException: For input string: \"%d\"",
+	}
+)
+
+func javaFrame(r *rand.Rand) string {
+	class := pick(r, javaClasses...)
+	return fmt.Sprintf("\tat %s.%s.%s(%s.java:%d)", pick(r, javaPackages...), class, pick(r, javaMethods...), class, 20+r.Intn(400))
+}
+
+func javaFrames(r *rand.Rand, min, max int) []string {
+	lines := []string{}
+	for i := depth(r, min, max); i > 0; i-- {
+		lines = append(lines, javaFrame(r))
+	}
+	for i := depth(r, 1, 4); i > 0; i-- {
+		lines = append(lines, "\tat "+pick(r, javaLibrary...))
+	}
+	return lines
+}
+
+func javaTrace(r *rand.Rand) string {
+	lines := []string{fmt.Sprintf("Exception in thread \"%s\" ", pick(r, "main", "http-nio-8080-exec-3", "pool-2-thread-1", "scheduling-1")) + fill(r, pick(r, javaExceptions...))}
+	lines = append(lines, javaFrames(r, 2, 10)...)
+
+	for i := r.Intn(3); i > 0; i-- {
+		lines = append(lines, "Caused by: "+fill(r, pick(r, javaCauses...)))
+		lines = append(lines, javaFrames(r, 1, 5)...)
+		lines = append(lines, fmt.Sprintf("\t... %d more", depth(r, 3, 40)))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+var (
+	pythonFiles      = []string{"/app/shop/orders.py", "/app/shop/payment/client.py", "/app/shop/views.py", "/usr/local/lib/python3.12/site-packages/requests/adapters.py", "/usr/local/lib/python3.12/site-packages/django/core/handlers/base.py"}
+	pythonFunctions  = []string{"process", "get_response", "_fetch", "send", "handle_order", "<module>", "wrapper"}
+	pythonStatements = []string{"result = handler(order)", "response = self.session.get(url, timeout=timeout)", "return int(value)", "raise ConnectionError(e, request=request)", "total = sum(item.price for item in order.items)", "data = json.loads(body)"}
+	pythonErrors     = []string{
+		"ValueError: invalid literal for int() with base 10: 'abc'",
+		"KeyError: 'customer_id'",
+		"AttributeError: 'NoneType' object has no attribute 'price'",
+		"requests.exceptions.ConnectionError: HTTPConnectionPool(host='payment', port=8080): Max retries exceeded with url: /charge",
+		"json.decoder.JSONDecodeError: Expecting value: line 1 column 1 (char 0)",
+		"ZeroDivisionError: division by zero",
+	}
+)
+
+func pythonTraceback(r *rand.Rand) []string {
+	lines := []string{"Traceback (most recent call last):"}
+	for i := depth(r, 1, 8); i > 0; i-- {
+		lines = append(lines,
+			fmt.Sprintf("  File \"%s\", line %d, in %s", pick(r, pythonFiles...), 10+r.Intn(500), pick(r, pythonFunctions...)),
+			"    "+pick(r, pythonStatements...),
+		)
+	}
+	return append(lines, pick(r, pythonErrors...))
+}
+
+func pythonTrace(r *rand.Rand) string {
+	lines := pythonTraceback(r)
+	if r.Intn(3) == 0 {
+		lines = append(lines, "", pick(r,
+			"During handling of the above exception, another exception occurred:",
+			"The above exception was the direct cause of the following exception:",
+		), "")
+		lines = append(lines, pythonTraceback(r)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+var (
+	goFunctions = []string{"main.(*Server).handleOrder", "main.(*Server).ServeHTTP", "github.com/example/shop/orders.(*Repository).Find", "github.com/example/shop/payment.Charge", "main.worker", "main.main"}
+	goFiles     = []string{"/app/server.go", "/app/orders/repository.go", "/app/payment/client.go", "/app/main.go", "/app/worker.go"}
+	goPanics    = []string{
+		"panic: runtime error: invalid memory address or nil pointer dereference\n[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4%x]",
+		"panic: runtime error: index out of range [%d] with length 3",
+		"panic: assignment to entry in nil map",
+		"panic: order %d: unexpected state \"shipped\"",
+	}
+	goStates = []string{"chan receive", "select", "IO wait", "semacquire", "sleep", "chan send, 2 minutes"}
+)
+
+func goStack(r *rand.Rand, min, max int) []string {
+	lines := []string{}
+	for i := depth(r, min, max); i > 0; i-- {
+		lines = append(lines,
+			fmt.Sprintf("%s(0xc%09x, 0x%x)", pick(r, goFunctions...), r.Int63n(1<<36), r.Intn(1<<16)),
+			fmt.Sprintf("\t%s:%d +0x%x", pick(r, goFiles...), 10+r.Intn(300), r.Intn(0x200)),
+		)
+	}
+	return lines
+}
+
+func goTrace(r *rand.Rand) string {
+	lines := []string{
+		fill(r, pick(r, goPanics...)),
+		"",
+		fmt.Sprintf("goroutine %d [running]:", 1+r.Intn(100)),
+	}
+	lines = append(lines, goStack(r, 2, 8)...)
+
+	// GOTRACEBACK=all dumps the other goroutines as well
+	for i := r.Intn(4); i > 0; i-- {
+		lines = append(lines, "", fmt.Sprintf("goroutine %d [%s]:", 100+r.Intn(1000), pick(r, goStates...)))
+		lines = append(lines, goStack(r, 1, 4)...)
+		lines = append(lines,
+			fmt.Sprintf("created by %s in goroutine 1", pick(r, goFunctions...)),
+			fmt.Sprintf("\t%s:%d +0x%x", pick(r, goFiles...), 10+r.Intn(300), r.Intn(0x200)),
+		)
+	}
+
+	return strings.Join(append(lines, "exit status 2"), "\n")
+}
+
+var (
+	rubyFiles   = []string{"/app/app/models/order.rb", "/app/app/controllers/orders_controller.rb", "/app/lib/payment/client.rb", "/usr/local/bundle/gems/activerecord-7.1.3/lib/active_record/relation/finder_methods.rb", "/usr/local/bundle/gems/actionpack-7.1.3/lib/action_controller/metal/basic_implicit_render.rb"}
+	rubyMethods = []string{"total", "create", "block in process", "find", "send_action", "each", "charge!"}
+	rubyErrors  = []string{
+		"undefined method `price' for nil:NilClass (NoMethodError)",
+		"Couldn't find Order with 'id'=%d (ActiveRecord::RecordNotFound)",
+		"execution expired (Net::ReadTimeout)",
+		"divided by 0 (ZeroDivisionError)",
+	}
+)
+
+func rubyFrame(r *rand.Rand) string {
+	return fmt.Sprintf("%s:%d:in `%s'", pick(r, rubyFiles...), 5+r.Intn(200), pick(r, rubyMethods...))
+}
+
+func rubyTrace(r *rand.Rand) string {
+	lines := []string{rubyFrame(r) + ": " + fill(r, pick(r, rubyErrors...))}
+	for i := depth(r, 2, 12); i > 0; i-- {
+		lines = append(lines, "\tfrom "+rubyFrame(r))
+	}
+	return strings.Join(lines, "\n")
+}
+
+var (
+	nodeFunctions = []string{"OrderService.process", "PaymentClient.charge", "Router.handle", "Layer.handle [as handle_request]", "async Promise.all (index 0)", "Object.<anonymous>"}
+	nodeFiles     = []string{"/app/src/orders/service.js", "/app/src/payment/client.js", "/app/node_modules/express/lib/router/layer.js", "/app/node_modules/express/lib/router/index.js", "/app/dist/server.js"}
+	nodeInternals = []string{"process.processTicksAndRejections (node:internal/process/task_queues:95:5)", "listOnTimeout (node:internal/timers:573:17)", "Socket.emit (node:events:519:28)"}
+	nodeErrors    = []string{
+		"TypeError: Cannot read properties of undefined (reading 'id')",
+		"ReferenceError: customer is not defined",
+		"Error: connect ECONNREFUSED 10.0.12.4:%d",
+		"SyntaxError: Unexpected token < in JSON at position 0",
+		"RangeError: Maximum call stack size exceeded",
+	}
+)
+
+func nodeTrace(r *rand.Rand) string {
+	lines := []string{fill(r, pick(r, nodeErrors...))}
+	for i := depth(r, 2, 10); i > 0; i-- {
+		fn := pick(r, nodeFunctions...)
+		if strings.HasPrefix(fn, "async Promise") {
+			lines = append(lines, "    at "+fn)
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("    at %s (%s:%d:%d)", fn, pick(r, nodeFiles...), 1+r.Intn(300), 1+r.Intn(60)))
+	}
+	lines = append(lines, "    at "+pick(r, nodeInternals...))
+	return strings.Join(lines, "\n")
+}
+
+var (
+	dotnetMethods = []string{
+		"Shop.Orders.OrderService.Process(Int32 orderId)",
+		"Shop.Orders.OrderRepository.FindAsync(Guid id, CancellationToken token)",
+		"Shop.Payment.PaymentClient.ChargeAsync(Decimal amount)",
+		"Shop.Api.Controllers.OrdersController.Post(OrderRequest request)",
+	}
+	dotnetFiles     = []string{"/src/Shop/Orders/OrderService.cs", "/src/Shop/Orders/OrderRepository.cs", "/src/Shop/Payment/PaymentClient.cs", "/src/Shop.Api/Controllers/OrdersController.cs"}
+	dotnetFramework = []string{
+		"System.Linq.ThrowHelper.ThrowNoElementsException()",
+		"System.Runtime.CompilerServices.TaskAwaiter.ThrowForNonSuccess(Task task)",
+		"Microsoft.AspNetCore.Mvc.Infrastructure.ActionMethodExecutor.TaskOfIActionResultExecutor.Execute(IActionResultTypeMapper mapper, ObjectMethodExecutor executor, Object controller, Object[] arguments)",
+		"Microsoft.EntityFrameworkCore.Storage.RelationalCommand.ExecuteReaderAsync(RelationalCommandParameterObject parameterObject, CancellationToken cancellationToken)",
+	}
+	dotnetExceptions = []string{
+		"System.InvalidOperationException: Sequence contains no elements",
+		"System.NullReferenceException: Object reference not set to an instance of an object.",
+		"System.ArgumentOutOfRangeException: Index was out of range. Must be non-negative and less than the size of the collection. (Parameter 'index')",
+		"System.Net.Http.HttpRequestException: Connection refused (payment:8080)",
+		"Microsoft.Data.SqlClient.SqlException (0x80131904): Execution Timeout Expired.",
+	}
+)
+
+func dotnetFrames(r *rand.Rand, min, max int) []string {
+	lines := []string{}
+	if r.Intn(2) == 0 {
+		lines = append(lines, "   at "+pick(r, dotnetFramework...))
+	}
+	for i := depth(r, min, max); i > 0; i-- {
+		lines = append(lines, fmt.Sprintf("   at %s in %s:line %d", pick(r, dotnetMethods...), pick(r, dotnetFiles...), 10+r.Intn(300)))
+	}
+	return lines
+}
+
+func dotnetTrace(r *rand.Rand) string {
+	outer := pick(r, dotnetExceptions...)
+	if r.Intn(2) == 0 {
+		return strings.Join(append([]string{outer}, dotnetFrames(r, 2, 10)...), "\n")
+	}
+
+	lines := []string{outer, " ---> " + pick(r, dotnetExceptions...)}
+	lines = append(lines, dotnetFrames(r, 1, 6)...)
+	lines = append(lines, "   --- End of inner exception stack trace ---")
+	lines = append(lines, dotnetFrames(r, 2, 8)...)
+	return strings.Join(lines, "\n")
+}
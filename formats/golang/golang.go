@@ -27,6 +27,9 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats/langpack"
+	"github.com/kube-logging/log-generator/formats/tracing"
+	"github.com/kube-logging/log-generator/incidents"
 )
 
 type GolangLogIntensity struct {
@@ -43,7 +46,13 @@ type GolangLog struct {
 	Level       string `json:"level"`
 	MSG         string `json:"msg"`
 	Time        string `json:"time"`
+	// Trace context of the application span, empty unless tracing is enabled
+	tracing.Context
+	// traceparent header of the span, set when rendered
+	Traceparent string `json:"traceparent,omitempty"`
+	Stacktrace  string `json:"stacktrace,omitempty"`
 
+	time     time.Time
 	isFramed bool
 }
 
@@ -79,18 +88,31 @@ func NewGolangLogRandom(i GolangLogIntensity) *GolangLog {
 	if err != nil {
 		log.Error(err)
 	}
-	return &GolangLog{
+	g := &GolangLog{
 		Application: randomdata.StringSample("webshop", "blog"),
 		Environment: randomdata.StringSample("production", "sandbox", "demo"),
 		Component:   randomdata.StringSample("frontend", "backend", "worker"),
 		Level:       c.Pick().(string),
 		Time:        "",
+		Context:     tracing.Span(),
 	}
+	if rate := incidents.ErrorRate(g.Application); rate > 0 && rand.Float64() < rate {
+		g.Level = "error"
+	}
+	g.MSG = langpack.Mix(rand.New(rand.NewSource(rand.Int63())), g.newRandomMessage())
+	return g
 }
 
 func (g GolangLog) String() (string, float64) {
-	g.Time = time.Now().Format(conf.Viper.GetString("golang.time_format"))
-	g.MSG = g.newRandomMessage()
+	now := g.time
+	if now.IsZero() {
+		now = time.Now()
+	}
+	g.Time = now.Format(conf.Viper.GetString("golang.time_format"))
+	if g.MSG == "" {
+		g.MSG = g.newRandomMessage()
+	}
+	g.Traceparent = g.Context.Traceparent()
 
 	out, err := json.MarshalIndent(g, "", "  ")
 	if err != nil {
@@ -107,6 +129,14 @@ func (g GolangLog) String() (string, float64) {
 	return message, float64(len([]byte(message)))
 }
 
+func (l *GolangLog) Timestamp() time.Time {
+	return l.time
+}
+
+func (l *GolangLog) SetTime(t time.Time) {
+	l.time = t
+}
+
 func (l *GolangLog) IsFramed() bool {
 	return l.isFramed
 }
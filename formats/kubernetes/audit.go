@@ -0,0 +1,189 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"time"
+)
+
+// auditEvent is an audit.k8s.io/v1 Event, as written by the API server log
+// backend.
+type auditEvent struct {
+	Kind                     string            `json:"kind"`
+	APIVersion               string            `json:"apiVersion"`
+	Level                    string            `json:"level"`
+	AuditID                  string            `json:"auditID"`
+	Stage                    string            `json:"stage"`
+	RequestURI               string            `json:"requestURI"`
+	Verb                     string            `json:"verb"`
+	User                     auditUser         `json:"user"`
+	SourceIPs                []string          `json:"sourceIPs"`
+	UserAgent                string            `json:"userAgent"`
+	ObjectRef                auditObjectRef    `json:"objectRef"`
+	ResponseStatus           auditStatus       `json:"responseStatus"`
+	RequestReceivedTimestamp string            `json:"requestReceivedTimestamp"`
+	StageTimestamp           string            `json:"stageTimestamp"`
+	Annotations              map[string]string `json:"annotations,omitempty"`
+
+	latency time.Duration
+}
+
+type auditUser struct {
+	Username string   `json:"username"`
+	UID      string   `json:"uid,omitempty"`
+	Groups   []string `json:"groups"`
+}
+
+type auditObjectRef struct {
+	Resource    string `json:"resource"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	APIGroup    string `json:"apiGroup,omitempty"`
+	APIVersion  string `json:"apiVersion"`
+	Subresource string `json:"subresource,omitempty"`
+}
+
+type auditStatus struct {
+	Metadata struct{} `json:"metadata"`
+	Status   string   `json:"status,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Reason   string   `json:"reason,omitempty"`
+	Code     int      `json:"code"`
+}
+
+func (e auditEvent) severity() string {
+	return strconv.Itoa(e.ResponseStatus.Code)
+}
+
+type auditClient struct {
+	user      auditUser
+	userAgent string
+}
+
+var auditClients = []auditClient{
+	{auditUser{Username: "system:serviceaccount:kube-system:replicaset-controller", Groups: []string{"system:serviceaccounts", "system:serviceaccounts:kube-system", "system:authenticated"}}, "kube-controller-manager/v1.30.2 (linux/amd64) kubernetes/3968350/system:serviceaccount:kube-system:replicaset-controller"},
+	{auditUser{Username: "system:kube-scheduler", Groups: []string{"system:authenticated"}}, "kube-scheduler/v1.30.2 (linux/amd64) kubernetes/3968350/scheduler"},
+	{auditUser{Username: "system:node:worker-pool-a-7f9c", Groups: []string{"system:nodes", "system:authenticated"}}, "kubelet/v1.30.2 (linux/amd64) kubernetes/3968350"},
+	{auditUser{Username: "system:serviceaccount:logging:logging-operator", Groups: []string{"system:serviceaccounts", "system:serviceaccounts:logging", "system:authenticated"}}, "manager/v0.0.0 (linux/amd64) kubernetes/$Format"},
+	{auditUser{Username: "kubernetes-admin", Groups: []string{"system:masters", "system:authenticated"}}, "kubectl/v1.30.1 (darwin/arm64) kubernetes/6911225"},
+	{auditUser{Username: "alice@example.com", Groups: []string{"developers", "system:authenticated"}}, "kubectl/v1.29.4 (linux/amd64) kubernetes/55019c8"},
+}
+
+var auditResources = []auditObjectRef{
+	{Resource: "pods", APIVersion: "v1"},
+	{Resource: "configmaps", APIVersion: "v1"},
+	{Resource: "secrets", APIVersion: "v1"},
+	{Resource: "leases", APIGroup: "coordination.k8s.io", APIVersion: "v1"},
+	{Resource: "deployments", APIGroup: "apps", APIVersion: "v1"},
+	{Resource: "replicasets", APIGroup: "apps", APIVersion: "v1"},
+	{Resource: "events", APIGroup: "events.k8s.io", APIVersion: "v1"},
+	{Resource: "flows", APIGroup: "logging.banzaicloud.io", APIVersion: "v1beta1"},
+}
+
+func newAuditEvent(r *rand.Rand) event {
+	client := auditClients[r.Intn(len(auditClients))]
+	client.user.UID = uid(r)
+	ref := auditResources[r.Intn(len(auditResources))]
+	verb := pick(r, "get", "get", "list", "watch", "create", "update", "patch", "patch", "delete")
+
+	ref.Namespace = pick(r, namespaces...)
+	uri := "/api/" + ref.APIVersion
+	if ref.APIGroup != "" {
+		uri = "/apis/" + ref.APIGroup + "/" + ref.APIVersion
+	}
+	uri += "/namespaces/" + ref.Namespace + "/" + ref.Resource
+
+	switch verb {
+	case "list":
+		uri += "?limit=500&resourceVersion=0"
+	case "watch":
+		uri += fmt.Sprintf("?allowWatchBookmarks=true&resourceVersion=%d&timeout=9m21s&timeoutSeconds=561&watch=true", 1000000+r.Intn(9000000))
+	case "create":
+	default:
+		ref.Name = podName(r)
+		uri += "/" + ref.Name
+		if ref.Resource == "pods" && verb == "patch" {
+			ref.Subresource = "status"
+			uri += "/status"
+		}
+	}
+
+	e := auditEvent{
+		Kind:       "Event",
+		APIVersion: "audit.k8s.io/v1",
+		Level:      pick(r, "Metadata", "Metadata", "Request", "RequestResponse"),
+		AuditID:    uid(r),
+		Stage:      "ResponseComplete",
+		RequestURI: uri,
+		Verb:       verb,
+		User:       client.user,
+		SourceIPs:  []string{fmt.Sprintf("10.0.%d.%d", r.Intn(4), 1+r.Intn(254))},
+		UserAgent:  client.userAgent,
+		ObjectRef:  ref,
+		latency:    time.Duration(200+r.Intn(50000)) * time.Microsecond,
+	}
+	if ref.Resource == "secrets" {
+		// secrets are not logged with their content
+		e.Level = "Metadata"
+	}
+
+	e.ResponseStatus.Code = 200
+	switch n := r.Intn(100); {
+	case verb == "watch":
+		e.Stage = pick(r, "ResponseStarted", "ResponseComplete")
+		e.latency = time.Duration(r.Intn(561)) * time.Second
+	case n < 3:
+		e.ResponseStatus = auditStatus{Status: "Failure", Reason: "Forbidden", Code: 403,
+			Message: fmt.Sprintf("%s %q is forbidden: User %q cannot %s resource %q in API group %q in the namespace %q", ref.Resource, ref.Name, client.user.Username, verb, ref.Resource, ref.APIGroup, ref.Namespace)}
+	case n < 8 && verb != "create" && verb != "list":
+		e.ResponseStatus = auditStatus{Status: "Failure", Reason: "NotFound", Code: 404,
+			Message: fmt.Sprintf("%s %q not found", ref.Resource, ref.Name)}
+	case n < 12 && (verb == "update" || verb == "patch"):
+		e.ResponseStatus = auditStatus{Status: "Failure", Reason: "Conflict", Code: 409,
+			Message: fmt.Sprintf("Operation cannot be fulfilled on %s %q: the object has been modified; please apply your changes to the latest version and try again", ref.Resource, ref.Name)}
+	case verb == "create":
+		e.ResponseStatus.Code = 201
+	}
+
+	if e.ResponseStatus.Code != 403 {
+		e.Annotations = map[string]string{
+			"authorization.k8s.io/decision": "allow",
+			"authorization.k8s.io/reason":   "RBAC: allowed by ClusterRoleBinding \"" + pick(r, "system:controller:replicaset-controller", "cluster-admin", "logging-operator") + "\" of ClusterRole \"" + pick(r, "cluster-admin", "edit") + "\" to User \"" + client.user.Username + "\"",
+		}
+	} else {
+		e.Annotations = map[string]string{
+			"authorization.k8s.io/decision": "forbid",
+			"authorization.k8s.io/reason":   "",
+		}
+	}
+
+	return e
+}
+
+func renderAudit(ev event, t time.Time) string {
+	e := ev.(auditEvent)
+	e.RequestReceivedTimestamp = t.Add(-e.latency).UTC().Format("2006-01-02T15:04:05.000000Z")
+	e.StageTimestamp = t.UTC().Format("2006-01-02T15:04:05.000000Z")
+
+	out, err := json.Marshal(e)
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
@@ -0,0 +1,179 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/kube-logging/log-generator/formats/langpack"
+)
+
+type keyValue struct {
+	key   string
+	value interface{}
+}
+
+// klogEvent is a structured klog call, e.g. klog.InfoS or klog.ErrorS.
+type klogEvent struct {
+	level     byte
+	verbosity int
+	threadID  int
+	file      string
+	line      int
+	msg       string
+	err       string
+	values    []keyValue
+}
+
+func (e klogEvent) severity() string {
+	switch e.level {
+	case 'W':
+		return "warning"
+	case 'E':
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+type klogMessage struct {
+	level  byte
+	file   string
+	msg    string
+	err    string
+	values func(r *rand.Rand) []keyValue
+}
+
+func objectRef(r *rand.Rand) string {
+	return pick(r, namespaces...) + "/" + podName(r)
+}
+
+var klogMessages = []klogMessage{
+	{level: 'I', file: "kubelet.go", msg: "SyncLoop (PLEG): event for pod", values: func(r *rand.Rand) []keyValue {
+		return []keyValue{{"pod", objectRef(r)}, {"event", map[string]string{"ID": uid(r), "Type": pick(r, "ContainerStarted", "ContainerDied"), "Data": fmt.Sprintf("%x", r.Int63())}}}
+	}},
+	{level: 'I', file: "kubelet.go", msg: "SyncLoop ADD", values: func(r *rand.Rand) []keyValue {
+		return []keyValue{{"source", "api"}, {"pods", []string{objectRef(r)}}}
+	}},
+	{level: 'I', file: "schedule_one.go", msg: "Successfully bound pod to node", values: func(r *rand.Rand) []keyValue {
+		return []keyValue{{"pod", objectRef(r)}, {"node", pick(r, nodes...)}, {"evaluatedNodes", 3 + r.Intn(20)}, {"feasibleNodes", 1 + r.Intn(3)}}
+	}},
+	{level: 'I', file: "event.go", msg: "Event occurred", values: func(r *rand.Rand) []keyValue {
+		return []keyValue{{"object", objectRef(r)}, {"fieldPath", ""}, {"kind", "Pod"}, {"apiVersion", "v1"}, {"type", "Normal"}, {"reason", pick(r, "Pulled", "Created", "Started", "Scheduled")}, {"message", "Container image already present on machine"}}
+	}},
+	{level: 'I', file: "controller_utils.go", msg: "Too few replicas", values: func(r *rand.Rand) []keyValue {
+		return []keyValue{{"replicaSet", pick(r, namespaces...) + "/" + pick(r, workloads...)}, {"need", 3}, {"creating", 1}}
+	}},
+	{level: 'I', file: "httplog.go", msg: "HTTP", values: func(r *rand.Rand) []keyValue {
+		return []keyValue{{"verb", pick(r, "GET", "LIST", "WATCH", "PATCH")}, {"URI", "/api/v1/namespaces/" + pick(r, namespaces...) + "/pods"}, {"latency", fmt.Sprintf("%.6fms", r.Float64()*50)}, {"userAgent", "kubelet/v1.30.2 (linux/amd64) kubernetes/3968350"}, {"audit-ID", uid(r)}, {"srcIP", fmt.Sprintf("10.0.%d.%d:%d", r.Intn(4), 1+r.Intn(254), 30000+r.Intn(30000))}, {"resp", 200}}
+	}},
+	{level: 'W', file: "reflector.go", msg: "watch of *v1.Pod ended with: an error on the server (\"unable to decode an event from the watch stream: http2: client connection lost\") has prevented the request from succeeding", values: func(r *rand.Rand) []keyValue {
+		return nil
+	}},
+	{level: 'W', file: "warnings.go", msg: "Warning: v1 Endpoints is deprecated in v1.33+; use discovery.k8s.io/v1 EndpointSlice", values: func(r *rand.Rand) []keyValue {
+		return nil
+	}},
+	{level: 'E', file: "pod_workers.go", msg: "Error syncing pod, skipping", err: "failed to \"StartContainer\" for \"app\" with CrashLoopBackOff: \"back-off 5m0s restarting failed container\"", values: func(r *rand.Rand) []keyValue {
+		return []keyValue{{"pod", objectRef(r)}, {"podUID", uid(r)}}
+	}},
+	{level: 'E', file: "leaderelection.go", msg: "Failed to update lock", err: "Put \"https://10.96.0.1:443/apis/coordination.k8s.io/v1/namespaces/kube-system/leases/kube-scheduler\": context deadline exceeded", values: func(r *rand.Rand) []keyValue {
+		return nil
+	}},
+}
+
+func newKlogEvent(r *rand.Rand) event {
+	m := klogMessages[r.Intn(len(klogMessages))]
+	return klogEvent{
+		level:     m.level,
+		verbosity: pickVerbosity(r, m.level),
+		threadID:  1 + r.Intn(3000),
+		file:      m.file,
+		line:      20 + r.Intn(1500),
+		msg:       langpack.Mix(r, m.msg),
+		err:       m.err,
+		values:    m.values(r),
+	}
+}
+
+func pickVerbosity(r *rand.Rand, level byte) int {
+	if level != 'I' {
+		return 0
+	}
+	return []int{0, 0, 0, 2, 4}[r.Intn(5)]
+}
+
+// renderKlogText renders the klog header and the structured message, e.g.
+//
+//	I0102 15:04:05.000000    1234 file.go:123] "msg" key="val"
+func renderKlogText(e event, t time.Time) string {
+	k := e.(klogEvent)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%c%s %7d %s:%d] %s", k.level, t.Format("0102 15:04:05.000000"), k.threadID, k.file, k.line, quote(k.msg))
+	if k.err != "" {
+		fmt.Fprintf(&b, " err=%s", quote(k.err))
+	}
+	for _, kv := range k.values {
+		fmt.Fprintf(&b, " %s=%s", kv.key, klogValue(kv.value))
+	}
+	return b.String()
+}
+
+func klogValue(v interface{}) string {
+	switch value := v.(type) {
+	case string:
+		return quote(value)
+	case int:
+		return fmt.Sprint(value)
+	case []string:
+		quoted := make([]string, len(value))
+		for i, s := range value {
+			quoted[i] = quote(s)
+		}
+		return "[" + strings.Join(quoted, ",") + "]"
+	default:
+		out, _ := json.Marshal(value)
+		return string(out)
+	}
+}
+
+// renderKlogJSON renders the JSON format of Kubernetes components, with the
+// timestamp in milliseconds.
+func renderKlogJSON(e event, t time.Time) string {
+	k := e.(klogEvent)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `{"ts":%.3f,"caller":"%s:%d","msg":%s`, float64(t.UnixMicro())/1e3, k.file, k.line, jsonString(k.msg))
+	if k.err != "" {
+		fmt.Fprintf(&b, `,"err":%s`, jsonString(k.err))
+	} else {
+		fmt.Fprintf(&b, `,"v":%d`, k.verbosity)
+	}
+	for _, kv := range k.values {
+		value, _ := json.Marshal(kv.value)
+		fmt.Fprintf(&b, `,%s:%s`, jsonString(kv.key), value)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+func jsonString(s string) string {
+	out, _ := json.Marshal(s)
+	return string(out)
+}
@@ -0,0 +1,137 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubernetes
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kube-logging/log-generator/log"
+)
+
+// generators create the content of the events of a format, and renderers
+// format it when the event is emitted.
+var generators = map[string]func(r *rand.Rand) event{
+	"klog":      newKlogEvent,
+	"klog.json": newKlogEvent,
+	"audit":     newAuditEvent,
+}
+
+var renderers = map[string]func(e event, t time.Time) string{
+	"klog":      renderKlogText,
+	"klog.json": renderKlogJSON,
+	"audit":     renderAudit,
+}
+
+func Formats() []string {
+	formats := make([]string, 0, len(generators))
+	for f := range generators {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// event is either a klog line or an audit event.
+type event interface {
+	severity() string
+}
+
+type Kubernetes struct {
+	Format string
+
+	event    event
+	time     time.Time
+	isFramed bool
+}
+
+// NewKubernetes returns a log line of a Kubernetes component. Without
+// randomise, the same event is returned every time.
+func NewKubernetes(format string, randomise bool) (*Kubernetes, error) {
+	generate, ok := generators[format]
+	if !ok {
+		return nil, fmt.Errorf("could not find format %q", format)
+	}
+
+	seed := int64(1)
+	if randomise {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Kubernetes{
+		Format: format,
+		event:  generate(rand.New(rand.NewSource(seed))),
+	}, nil
+}
+
+func (k *Kubernetes) String() (string, float64) {
+	msg := renderers[k.Format](k.event, log.TimeOrNow(k.time))
+	return msg, float64(len(msg))
+}
+
+func (k *Kubernetes) Timestamp() time.Time {
+	return k.time
+}
+
+func (k *Kubernetes) SetTime(t time.Time) {
+	k.time = t
+}
+
+func (k *Kubernetes) IsFramed() bool {
+	return k.isFramed
+}
+
+func (k *Kubernetes) SetFramed(f bool) {
+	k.isFramed = f
+}
+
+func (k *Kubernetes) Labels() prometheus.Labels {
+	return prometheus.Labels{
+		"type":     "kubernetes." + k.Format,
+		"severity": k.event.severity(),
+	}
+}
+
+func pick(r *rand.Rand, items ...string) string {
+	return items[r.Intn(len(items))]
+}
+
+var (
+	namespaces = []string{"default", "kube-system", "monitoring", "logging", "shop", "ingress-nginx", "cert-manager"}
+	workloads  = []string{"nginx", "coredns", "fluent-bit", "prometheus", "checkout", "cart", "payment", "fluentd", "cert-manager-webhook"}
+	nodes      = []string{"ip-10-0-1-17.ec2.internal", "ip-10-0-2-143.ec2.internal", "worker-pool-a-7f9c", "worker-pool-b-2d41", "control-plane-0"}
+)
+
+func podName(r *rand.Rand) string {
+	const chars = "bcdfghjklmnpqrstvwxz2456789"
+	suffix := make([]byte, 5)
+	for i := range suffix {
+		suffix[i] = chars[r.Intn(len(chars))]
+	}
+	return fmt.Sprintf("%s-%x-%s", pick(r, workloads...), 0x5c000000+r.Intn(0xfffffff), suffix)
+}
+
+func uid(r *rand.Rand) string {
+	return fmt.Sprintf("%08x-%04x-4%03x-%04x-%012x", r.Uint32(), r.Intn(1<<16), r.Intn(1<<12), 0x8000|r.Intn(1<<14), r.Int63n(1<<48))
+}
+
+func quote(s string) string {
+	return strconv.Quote(s)
+}
@@ -0,0 +1,179 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package langpack
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/conf"
+)
+
+// packs contain multi-byte content that breaks byte-based processing: wide
+// characters, right-to-left text mixed with numbers and Latin words,
+// combining characters, emoji ZWJ sequences and characters outside the Basic
+// Multilingual Plane.
+var packs = map[string][]string{
+	"cjk": {
+		"用户登录成功",
+		"服务暂时不可用，请稍后重试",
+		"注文を処理しました",
+		"データベース接続がタイムアウトしました",
+		"결제가 완료되었습니다",
+		"ｆｕｌｌｗｉｄｔｈ　ｔｅｘｔ",
+	},
+	"rtl": {
+		"تم تسجيل الدخول بنجاح",
+		"خطأ في الاتصال بالخادم 503",
+		"الطلب رقم 42 (order) قيد المعالجة",
+		"ההזמנה נשלחה בהצלחה",
+		"משתמש 1042 התנתק מ-api.example.com",
+		"\u202eRTL override\u202c",
+	},
+	"combining": {
+		"Zoe\u0308 cafe\u0301 re\u0301sume\u0301",
+		"Tie\u0302\u0301ng Vie\u0323\u0302t",
+		"a\u0300\u0301\u0302\u0303\u0308\u030a stacked marks",
+		"नमस्ते दुनिया",
+		"ผู้ใช้เข้าสู่ระบบแล้ว",
+		"\u1100\u1161\u11a8 vs \uac01",
+	},
+	"emoji": {
+		"deploy \U0001F680 done \u2705",
+		"\U0001F469\u200d\U0001F4BB on call",
+		"\U0001F468\u200d\U0001F469\u200d\U0001F467\u200d\U0001F466 family plan",
+		"\U0001F44D\U0001F3FD approved",
+		"\U0001F3F3\ufe0f\u200d\U0001F308 \U0001F1ED\U0001F1FA \U0001F1EF\U0001F1F5",
+		"1\ufe0f\u20e3 first retry \u26a0\ufe0f",
+	},
+	"astral": {
+		"𝔘𝔫𝔦𝔠𝔬𝔡𝔢 𝕥𝕖𝕩𝕥",
+		"𠜎𠜱𠝹𠱓",
+		"𓂀 𓃰 𓆣",
+		"𝄞 𝄢 𝅘𝅥𝅮",
+		"🀄 🂡 🃏",
+	},
+	"european": {
+		"Grüße aus Köln, Straße 5",
+		"Ελληνικά μηνύματα",
+		"Привет, мир",
+		"Łódź, Kraków, Gdańsk",
+		"naïve façade — “quoted” ‘text’ …",
+	},
+}
+
+// Names returns the names of the language packs.
+func Names() []string {
+	names := make([]string, 0, len(packs))
+	for name := range packs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Config configures the multilingual content of messages and field values.
+type Config struct {
+	// Ratio is the fraction of messages and field values with content of a
+	// language pack.
+	Ratio float64
+	// Packs lists the language packs to use, all of them if empty.
+	Packs string
+}
+
+func ConfigFromViper() Config {
+	config := Config{
+		Packs: conf.Viper.GetString("langpack.packs"),
+	}
+	if conf.Viper.GetBool("langpack.enabled") {
+		config.Ratio = conf.Viper.GetFloat64("langpack.ratio")
+	}
+	return config
+}
+
+// Mixer injects content of language packs.
+type Mixer struct {
+	ratio   float64
+	phrases []string
+}
+
+func NewMixer(config Config) (*Mixer, error) {
+	if config.Ratio < 0 || config.Ratio > 1 {
+		return nil, fmt.Errorf("ratio must be between 0 and 1, got %v", config.Ratio)
+	}
+
+	m := &Mixer{ratio: config.Ratio}
+
+	names := strings.Split(config.Packs, ",")
+	if strings.TrimSpace(config.Packs) == "" {
+		names = Names()
+	}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		pack, ok := packs[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown language pack %q", name)
+		}
+		m.phrases = append(m.phrases, pack...)
+	}
+
+	return m, nil
+}
+
+// Mix returns s with a phrase of a language pack inserted between two of its
+// words, for the configured ratio of the calls.
+func (m *Mixer) Mix(r *rand.Rand, s string) string {
+	if m.ratio == 0 || r.Float64() >= m.ratio {
+		return s
+	}
+	phrase := m.phrases[r.Intn(len(m.phrases))]
+	if s == "" {
+		return phrase
+	}
+
+	words := strings.Split(s, " ")
+	i := r.Intn(len(words) + 1)
+	return strings.Join(append(words[:i], append([]string{phrase}, words[i:]...)...), " ")
+}
+
+var (
+	defaultMixer     *Mixer
+	defaultMixerErr  error
+	defaultMixerOnce sync.Once
+)
+
+// LoadMixer builds the Mixer configured by the [langpack] config section,
+// once, so an invalid config is reported at startup.
+func LoadMixer() (*Mixer, error) {
+	defaultMixerOnce.Do(func() {
+		defaultMixer, defaultMixerErr = NewMixer(ConfigFromViper())
+	})
+	return defaultMixer, defaultMixerErr
+}
+
+// Mix injects content of the language packs configured in the [langpack]
+// config section into s.
+func Mix(r *rand.Rand, s string) string {
+	m, err := LoadMixer()
+	if err != nil {
+		logger.Fatalf("invalid langpack config: %v", err)
+	}
+	return m.Mix(r, s)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package langpack
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPacks(t *testing.T) {
+	for name, pack := range packs {
+		for _, phrase := range pack {
+			if !utf8.ValidString(phrase) || utf8.RuneCountInString(phrase) == len(phrase) {
+				t.Errorf("Expected valid multi-byte content, pack=%q, %q", name, phrase)
+			}
+		}
+	}
+}
+
+func TestMix(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	m, err := NewMixer(Config{Ratio: 1, Packs: "emoji"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		mixed := m.Mix(r, "request completed")
+		if !strings.Contains(strings.Join(strings.Fields(mixed), " "), "request") || utf8.RuneCountInString(mixed) == len(mixed) {
+			t.Errorf("Expected emoji in %q", mixed)
+		}
+	}
+
+	m, err = NewMixer(Config{Ratio: 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mixed := m.Mix(r, "request completed"); mixed != "request completed" {
+		t.Errorf("Expected unchanged message, got %q", mixed)
+	}
+
+	if _, err := NewMixer(Config{Ratio: 1, Packs: "klingon"}); err == nil {
+		t.Errorf("Expected error for unknown pack")
+	}
+}
@@ -19,7 +19,15 @@ import (
 	"io/fs"
 
 	"github.com/kube-logging/log-generator/formats/custom"
+	"github.com/kube-logging/log-generator/formats/database"
+	"github.com/kube-logging/log-generator/formats/exceptions"
 	"github.com/kube-logging/log-generator/formats/golang"
+	"github.com/kube-logging/log-generator/formats/kubernetes"
+	"github.com/kube-logging/log-generator/formats/logfmt"
+	"github.com/kube-logging/log-generator/formats/network"
+	"github.com/kube-logging/log-generator/formats/replay"
+	"github.com/kube-logging/log-generator/formats/security"
+	"github.com/kube-logging/log-generator/formats/structured"
 	"github.com/kube-logging/log-generator/formats/web"
 	"github.com/kube-logging/log-generator/log"
 )
@@ -30,6 +38,14 @@ func FormatsByType() map[string][]string {
 		response[t] = f
 	}
 	response["web"] = WebFormatNames()
+	response["exceptions"] = exceptions.Formats()
+	response["structured"] = structured.Formats()
+	response["kubernetes"] = kubernetes.Formats()
+	response["security"] = security.Formats()
+	response["database"] = database.Formats()
+	response["network"] = network.Formats()
+	response["logfmt"] = logfmt.Formats()
+	response["replay"] = replay.Formats()
 	return response
 }
 
@@ -41,6 +57,22 @@ func LogFactory(logType string, format string, randomise bool) (log.Log, error)
 		} else {
 			return NewWeb(format, web.TemplateFS)
 		}
+	case "exceptions":
+		return exceptions.NewException(format, randomise)
+	case "structured":
+		return structured.NewStructured(format, randomise)
+	case "kubernetes":
+		return kubernetes.NewKubernetes(format, randomise)
+	case "security":
+		return security.NewSecurity(format, randomise)
+	case "database":
+		return database.NewDatabase(format, randomise)
+	case "network":
+		return network.NewNetwork(format, randomise)
+	case "logfmt":
+		return logfmt.NewLogfmt(format, randomise)
+	case "replay":
+		return replay.NewReplay(format, randomise)
 	default:
 		return custom.LogFactory(logType, format, randomise)
 	}
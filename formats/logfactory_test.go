@@ -16,9 +16,16 @@ package formats
 
 import (
 	"embed"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats/golang"
+	"github.com/kube-logging/log-generator/formats/tracing"
 	"github.com/kube-logging/log-generator/formats/web"
 	"github.com/kube-logging/log-generator/log"
 )
@@ -31,6 +38,28 @@ func TestWebFormats(t *testing.T) {
 	assertFormatAll(t, web.TemplateFS, NewWeb)
 }
 
+func TestTraefikFields(t *testing.T) {
+	l, err := NewWeb("traefik.json", web.TemplateFS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	line, _ := l.String()
+
+	var fields struct {
+		Duration, OriginDuration, Overhead int64
+		RequestCount                       uint64
+	}
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		t.Fatalf("Invalid traefik.json line %q: %v", line, err)
+	}
+	if fields.Overhead != fields.Duration-fields.OriginDuration || fields.Overhead <= 0 {
+		t.Errorf("Expected the overhead to be the duration not spent upstream, got %+v", fields)
+	}
+	if fields.RequestCount != 1 {
+		t.Errorf("Expected a request count of 1, got %d", fields.RequestCount)
+	}
+}
+
 func assertFormatAll(t *testing.T, embeddedTemplates embed.FS, c LogConstructor) {
 	templates := log.LoadAllTemplates(embeddedTemplates)
 	for _, f := range templates {
@@ -45,3 +74,224 @@ func assertFormatAll(t *testing.T, embeddedTemplates embed.FS, c LogConstructor)
 		}
 	}
 }
+
+func TestExceptionFormats(t *testing.T) {
+	conf.Init()
+
+	for _, format := range FormatsByType()["exceptions"] {
+		l, err := LogFactory("exceptions", format, false)
+		if err != nil {
+			t.Fatalf("Failed to create log, format=%q, %v", format, err)
+		}
+
+		trace, _ := l.String()
+		if lines := strings.Split(trace, "\n"); len(lines) < 3 {
+			t.Errorf("Expected a multi-line stack trace, format=%q, got %q", format, trace)
+		}
+
+		same, _ := LogFactory("exceptions", format, false)
+		if other, _ := same.String(); other != trace {
+			t.Errorf("Expected the same trace without randomise, format=%q", format)
+		}
+	}
+}
+
+func TestStructuredFormats(t *testing.T) {
+	conf.Init()
+
+	for _, format := range FormatsByType()["structured"] {
+		l, err := LogFactory("structured", format, true)
+		if err != nil {
+			t.Fatalf("Failed to create log, format=%q, %v", format, err)
+		}
+
+		line, _ := l.String()
+		if !json.Valid([]byte(line)) {
+			t.Errorf("Rendered log is not valid JSON, format=%q, %q", format, line)
+		}
+	}
+}
+
+func TestKubernetesFormats(t *testing.T) {
+	for _, format := range FormatsByType()["kubernetes"] {
+		l, err := LogFactory("kubernetes", format, true)
+		if err != nil {
+			t.Fatalf("Failed to create log, format=%q, %v", format, err)
+		}
+
+		line, _ := l.String()
+		if isJSON := format != "klog"; isJSON != json.Valid([]byte(line)) {
+			t.Errorf("Unexpected rendered log, format=%q, %q", format, line)
+		}
+	}
+}
+
+func TestSecurityFormats(t *testing.T) {
+	prefixes := map[string]string{"cef": "CEF:0|", "leef": "LEEF:2.0|", "windows.xml": "<Event ", "windows.json": "{"}
+
+	for _, format := range FormatsByType()["security"] {
+		base, syslog, _ := strings.Cut(format, ".")
+		if base == "windows" {
+			base, syslog = format, ""
+		}
+
+		// events are random, render a few to hit the values that need escaping
+		for i := 0; i < 50; i++ {
+			l, err := LogFactory("security", format, true)
+			if err != nil {
+				t.Fatalf("Failed to create log, format=%q, %v", format, err)
+			}
+
+			line, _ := l.String()
+			if strings.ContainsAny(line, "\r\n") {
+				t.Fatalf("Unescaped line break, format=%q, %q", format, line)
+			}
+			if syslog != "" {
+				_, line, _ = strings.Cut(line, prefixes[base])
+				line = prefixes[base] + line
+			}
+			if !strings.HasPrefix(line, prefixes[base]) {
+				t.Fatalf("Unexpected rendered log, format=%q, %q", format, line)
+			}
+
+			switch base {
+			case "windows.xml":
+				if err := xml.Unmarshal([]byte(line), new(struct{})); err != nil {
+					t.Fatalf("Invalid XML, format=%q, %v, %q", format, err, line)
+				}
+			case "windows.json":
+				if !json.Valid([]byte(line)) {
+					t.Fatalf("Invalid JSON, format=%q, %q", format, line)
+				}
+			}
+		}
+	}
+}
+
+func TestDatabaseFormats(t *testing.T) {
+	conf.Init()
+	for _, format := range FormatsByType()["database"] {
+		for i := 0; i < 20; i++ {
+			l, err := LogFactory("database", format, true)
+			if err != nil {
+				t.Fatalf("Failed to create log, format=%q, %v", format, err)
+			}
+
+			line, _ := l.String()
+			switch format {
+			case "postgresql.json", "mongodb":
+				if !json.Valid([]byte(line)) {
+					t.Fatalf("Invalid JSON, format=%q, %q", format, line)
+				}
+				if strings.Contains(line, ` ",`) {
+					t.Fatalf("Padded field value, format=%q, %q", format, line)
+				}
+			case "postgresql.csv":
+				if _, err := csv.NewReader(strings.NewReader(line)).Read(); err != nil {
+					t.Fatalf("Invalid CSV, format=%q, %v, %q", format, err, line)
+				}
+			case "mysql.slow":
+				if lines := strings.Split(line, "\n"); len(lines) < 5 {
+					t.Fatalf("Expected a multi-line entry, format=%q, %q", format, line)
+				}
+			}
+		}
+	}
+}
+
+func TestNetworkFormats(t *testing.T) {
+	conf.Init()
+
+	fields := map[string]int{"vpcflow": 14, "vpcflow.v3": 21, "vpcflow.v4": 25, "vpcflow.v5": 29}
+	for _, format := range FormatsByType()["network"] {
+		l, err := LogFactory("network", format, true)
+		if err != nil {
+			t.Fatalf("Failed to create log, format=%q, %v", format, err)
+		}
+
+		line, _ := l.String()
+		switch {
+		case fields[format] > 0:
+			if n := len(strings.Fields(line)); n != fields[format] {
+				t.Errorf("Expected %d fields, format=%q, got %d, %q", fields[format], format, n, line)
+			}
+		case format == "cloudtrail":
+			if !json.Valid([]byte(line)) {
+				t.Errorf("Invalid JSON, format=%q, %q", format, line)
+			}
+		case format == "csv":
+			if n := len(strings.Split(line, ",")); n != 10 {
+				t.Errorf("Expected 10 fields, format=%q, got %d, %q", format, n, line)
+			}
+		}
+	}
+}
+
+func TestLogfmtFormats(t *testing.T) {
+	conf.Init()
+	conf.Viper.Set("logfmt.schemas.payments.separator", "|")
+	conf.Viper.Set("logfmt.schemas.payments.assign", ":")
+	conf.Viper.Set("logfmt.schemas.payments.fields", `service:const(payments), level:enum(warn), msg:const(card "4242" declined|retry)`)
+
+	for _, format := range FormatsByType()["logfmt"] {
+		l, err := LogFactory("logfmt", format, true)
+		if err != nil {
+			t.Fatalf("Failed to create log, format=%q, %v", format, err)
+		}
+
+		line, _ := l.String()
+		switch format {
+		case "payments":
+			if expected := `service:payments|level:warn|msg:"card \"4242\" declined|retry"`; line != expected {
+				t.Errorf("Expected %q, got %q", expected, line)
+			}
+			if severity := l.Labels()["severity"]; severity != "warn" {
+				t.Errorf("Expected severity warn, got %q", severity)
+			}
+		default:
+			if !strings.HasPrefix(line, "time=") || strings.ContainsAny(line, "\n\r") {
+				t.Errorf("Invalid line, format=%q, %q", format, line)
+			}
+		}
+	}
+}
+
+func TestSyslogRFC5424(t *testing.T) {
+	conf.Init()
+
+	sdElement := `\[[^ =\]"]+( [^ =\]"]+="([^"\\\]]|\\["\\\]])*")*\]`
+	rfc5424 := regexp.MustCompile(`^<\d{1,3}>1 \S+ \S+ \S+ \S+ \S+ (-|(` + sdElement + `)+)( .+)?$`)
+
+	// messages are random, render a few to hit the values that need escaping
+	for i := 0; i < 100; i++ {
+		l, err := LogFactory("sysloglike", "syslog.rfc5424", i > 0)
+		if err != nil {
+			t.Fatalf("Failed to create log, %v", err)
+		}
+
+		line, _ := l.String()
+		if !rfc5424.MatchString(line) {
+			t.Fatalf("Invalid RFC 5424 message, %q", line)
+		}
+	}
+}
+
+func TestGolangTraceparent(t *testing.T) {
+	conf.Init()
+
+	c := tracing.Context{TraceID: "4bf92f3577b34da6a3ce929d0e0e4736", SpanID: "00f067aa0ba902b7", Sampled: true}
+	line, _ := (&golang.GolangLog{Level: "info", MSG: "served", Context: c}).String()
+
+	var fields map[string]string
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		t.Fatalf("Invalid golang line %q: %v", line, err)
+	}
+	if fields["traceparent"] != c.Traceparent() || fields["trace_id"] != c.TraceID || fields["span_id"] != c.SpanID {
+		t.Errorf("Expected the trace context of the span, got %q", line)
+	}
+
+	line, _ = (&golang.GolangLog{Level: "info", MSG: "served"}).String()
+	if strings.Contains(line, "traceparent") {
+		t.Errorf("Expected no traceparent without trace context, got %q", line)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logfmt
+
+import (
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// builtins are the encodings of the formats that use the fields of the
+// [logfmt] section.
+var builtins = map[string]encoding{
+	"logfmt":    {separator: " ", assign: "=", quote: `"`, quoting: quoteAuto},
+	"kv.comma":  {separator: ", ", assign: "=", quote: `"`, quoting: quoteAlways},
+	"kv.single": {separator: " ", assign: "=", quote: "'", quoting: quoteAuto},
+}
+
+// Formats returns the built-in formats and the schemas of the
+// [logfmt.schemas.<name>] config sections.
+func Formats() []string {
+	formats := make([]string, 0, len(builtins))
+	for f := range builtins {
+		formats = append(formats, f)
+	}
+	for f := range conf.Viper.GetStringMap("logfmt.schemas") {
+		if _, ok := builtins[f]; !ok {
+			formats = append(formats, f)
+		}
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+type Logfmt struct {
+	Format string
+
+	schema   *schema
+	values   []string
+	time     time.Time
+	isFramed bool
+}
+
+// NewLogfmt returns a key=value line with the fields of the schema of format.
+// Without randomise, the same values are returned every time.
+func NewLogfmt(format string, randomise bool) (*Logfmt, error) {
+	s, err := loadSchema(format)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := int64(1)
+	if randomise {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Logfmt{
+		Format: format,
+		schema: s,
+		values: s.generate(rand.New(rand.NewSource(seed))),
+	}, nil
+}
+
+func (l *Logfmt) String() (string, float64) {
+	msg := l.schema.render(l.values, log.TimeOrNow(l.time))
+	return msg, float64(len(msg))
+}
+
+func (l *Logfmt) Timestamp() time.Time {
+	return l.time
+}
+
+func (l *Logfmt) SetTime(t time.Time) {
+	l.time = t
+}
+
+func (l *Logfmt) IsFramed() bool {
+	return l.isFramed
+}
+
+func (l *Logfmt) SetFramed(f bool) {
+	l.isFramed = f
+}
+
+func (l *Logfmt) Labels() prometheus.Labels {
+	return prometheus.Labels{
+		"type":     "logfmt." + l.Format,
+		"severity": l.schema.severity(l.values),
+	}
+}
@@ -0,0 +1,429 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logfmt
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats/langpack"
+)
+
+const (
+	quoteAuto   = "auto"
+	quoteAlways = "always"
+	quoteNever  = "never"
+)
+
+// encoding is the syntax of the pairs of a line.
+type encoding struct {
+	separator string
+	assign    string
+	quote     string
+	quoting   string
+}
+
+// encode quotes v when the quoting rule requires it. Inside quotes, the quote
+// character and backslashes are escaped; control characters are escaped in
+// both cases, so a value never breaks the line.
+func (e encoding) encode(v string) string {
+	quoted := e.quoting == quoteAlways || e.quoting == quoteAuto && e.needsQuote(v)
+
+	var b strings.Builder
+	if quoted {
+		b.WriteString(e.quote)
+	}
+	for _, c := range v {
+		switch {
+		case c == '\n':
+			b.WriteString(`\n`)
+		case c == '\r':
+			b.WriteString(`\r`)
+		case c == '\t':
+			b.WriteString(`\t`)
+		case c < 0x20:
+			fmt.Fprintf(&b, `\u%04x`, c)
+		case quoted && (string(c) == e.quote || c == '\\'):
+			b.WriteByte('\\')
+			b.WriteRune(c)
+		default:
+			b.WriteRune(c)
+		}
+	}
+	if quoted {
+		b.WriteString(e.quote)
+	}
+	return b.String()
+}
+
+func (e encoding) needsQuote(v string) bool {
+	if v == "" || strings.ContainsAny(v, " "+e.quote+e.assign) {
+		return true
+	}
+	if sep := strings.TrimSpace(e.separator); sep != "" && strings.Contains(v, sep) {
+		return true
+	}
+	for _, c := range v {
+		if c < 0x20 || c == utf8.RuneError {
+			return true
+		}
+	}
+	return false
+}
+
+func (e encoding) validate() error {
+	switch {
+	case e.separator == "":
+		return fmt.Errorf("empty separator")
+	case e.assign == "":
+		return fmt.Errorf("empty assign")
+	case e.quoting != quoteAuto && e.quoting != quoteAlways && e.quoting != quoteNever:
+		return fmt.Errorf("unknown quoting %q", e.quoting)
+	case e.quoting != quoteNever && utf8.RuneCountInString(e.quote) != 1:
+		return fmt.Errorf("quote must be a single character, got %q", e.quote)
+	}
+	return nil
+}
+
+type field struct {
+	name string
+	// timeFormat is set for fields holding the time of the event, which is
+	// rendered when the event is emitted
+	timeFormat string
+	generate   func(r *rand.Rand) string
+}
+
+type schema struct {
+	encoding
+	fields        []field
+	severityField string
+}
+
+// loadSchema reads the schema of format. The fields and encoding of a
+// [logfmt.schemas.<name>] section default to the ones of the logfmt format.
+func loadSchema(format string) (*schema, error) {
+	key := "logfmt.schemas." + format
+
+	enc, builtin := builtins[format]
+	if !builtin {
+		if len(conf.Viper.GetStringMap(key)) == 0 {
+			return nil, fmt.Errorf("could not find format %q", format)
+		}
+		enc = builtins["logfmt"]
+	}
+	get := func(name, value string) string {
+		if conf.Viper.IsSet(key + "." + name) {
+			return conf.Viper.GetString(key + "." + name)
+		}
+		return value
+	}
+
+	s := &schema{
+		encoding: encoding{
+			separator: get("separator", enc.separator),
+			assign:    get("assign", enc.assign),
+			quote:     get("quote", enc.quote),
+			quoting:   get("quoting", enc.quoting),
+		},
+		severityField: get("severity_field", conf.Viper.GetString("logfmt.severity_field")),
+	}
+	if err := s.encoding.validate(); err != nil {
+		return nil, fmt.Errorf("format %q: %w", format, err)
+	}
+
+	fields, err := parseFields(get("fields", conf.Viper.GetString("logfmt.fields")))
+	if err != nil {
+		return nil, fmt.Errorf("format %q: %w", format, err)
+	}
+	s.fields = fields
+
+	return s, nil
+}
+
+func (s *schema) generate(r *rand.Rand) []string {
+	values := make([]string, len(s.fields))
+	for i, f := range s.fields {
+		if f.generate != nil {
+			values[i] = f.generate(r)
+		}
+	}
+	return values
+}
+
+func (s *schema) render(values []string, t time.Time) string {
+	var b strings.Builder
+	for i, f := range s.fields {
+		if i > 0 {
+			b.WriteString(s.separator)
+		}
+		v := values[i]
+		if f.timeFormat != "" {
+			v = formatTime(t, f.timeFormat)
+		}
+		b.WriteString(f.name)
+		b.WriteString(s.assign)
+		b.WriteString(s.encode(v))
+	}
+	return b.String()
+}
+
+// severity returns the value of the severity field of the schema.
+func (s *schema) severity(values []string) string {
+	for i, f := range s.fields {
+		if f.name == s.severityField && f.timeFormat == "" {
+			return values[i]
+		}
+	}
+	return "none"
+}
+
+// parseFields parses a comma separated list of name:generator(args) field
+// definitions.
+func parseFields(spec string) ([]field, error) {
+	var fields []field
+	for _, def := range strings.Split(spec, ",") {
+		def = strings.TrimSpace(def)
+		if def == "" {
+			continue
+		}
+
+		name, kind, _ := strings.Cut(def, ":")
+		name, kind = strings.TrimSpace(name), strings.TrimSpace(kind)
+		if name == "" || strings.ContainsAny(name, " \t\"'=") {
+			return nil, fmt.Errorf("invalid field name %q", name)
+		}
+
+		args := ""
+		if i := strings.IndexByte(kind, '('); i >= 0 {
+			if !strings.HasSuffix(kind, ")") {
+				return nil, fmt.Errorf("field %q: missing ) in %q", name, kind)
+			}
+			kind, args = kind[:i], kind[i+1:len(kind)-1]
+		}
+		newField, ok := generators[kind]
+		if !ok {
+			return nil, fmt.Errorf("field %q: unknown generator %q", name, kind)
+		}
+
+		f, err := newField(args)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", name, err)
+		}
+		f.name = name
+		fields = append(fields, f)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields configured")
+	}
+	return fields, nil
+}
+
+// generators parse the arguments of a generator and return a field with it.
+var generators = map[string]func(args string) (field, error){
+	"time":     newTimeField,
+	"const":    func(args string) (field, error) { return field{generate: func(*rand.Rand) string { return args }}, nil },
+	"enum":     newEnumField,
+	"int":      newIntField,
+	"duration": newDurationField,
+	"ip":       newIPField,
+	"uuid":     func(string) (field, error) { return field{generate: uuid}, nil },
+	"words":    newWordsField,
+}
+
+var timeLayouts = map[string]string{
+	"rfc3339":      time.RFC3339,
+	"rfc3339milli": "2006-01-02T15:04:05.000Z07:00",
+	"rfc3339nano":  time.RFC3339Nano,
+	"unix":         "",
+	"unixmilli":    "",
+}
+
+func newTimeField(args string) (field, error) {
+	if args == "" {
+		args = "rfc3339"
+	}
+	if _, ok := timeLayouts[args]; !ok {
+		return field{}, fmt.Errorf("unknown time format %q", args)
+	}
+	return field{timeFormat: args}, nil
+}
+
+func formatTime(t time.Time, format string) string {
+	switch format {
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "unixmilli":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	default:
+		return t.UTC().Format(timeLayouts[format])
+	}
+}
+
+// newEnumField parses a | separated list of values, each optionally followed
+// by =weight.
+func newEnumField(args string) (field, error) {
+	var values []string
+	var weights []int
+	total := 0
+	for _, v := range strings.Split(args, "|") {
+		weight := 1
+		if i := strings.LastIndexByte(v, '='); i >= 0 {
+			if w, err := strconv.Atoi(v[i+1:]); err == nil {
+				if w < 0 {
+					return field{}, fmt.Errorf("negative weight of %q", v[:i])
+				}
+				v, weight = v[:i], w
+			}
+		}
+		values = append(values, v)
+		weights = append(weights, weight)
+		total += weight
+	}
+	if total == 0 {
+		return field{}, fmt.Errorf("no values with positive weight in %q", args)
+	}
+
+	return field{generate: func(r *rand.Rand) string {
+		n := r.Intn(total)
+		for i, w := range weights {
+			if n < w {
+				return values[i]
+			}
+			n -= w
+		}
+		return values[len(values)-1]
+	}}, nil
+}
+
+// parseRange parses a min..max range.
+func parseRange(args string) (string, string, error) {
+	min, max, ok := strings.Cut(args, "..")
+	if !ok {
+		return "", "", fmt.Errorf("invalid range %q, expected min..max", args)
+	}
+	return strings.TrimSpace(min), strings.TrimSpace(max), nil
+}
+
+func newIntField(args string) (field, error) {
+	lo, hi, err := parseRange(args)
+	if err != nil {
+		return field{}, err
+	}
+	min, err := strconv.Atoi(lo)
+	if err != nil {
+		return field{}, err
+	}
+	max, err := strconv.Atoi(hi)
+	if err != nil {
+		return field{}, err
+	}
+	if max < min {
+		return field{}, fmt.Errorf("invalid range %q", args)
+	}
+
+	return field{generate: func(r *rand.Rand) string {
+		return strconv.Itoa(min + r.Intn(max-min+1))
+	}}, nil
+}
+
+// newDurationField returns durations distributed log-uniformly between min
+// and max, so short and long ones are equally represented.
+func newDurationField(args string) (field, error) {
+	lo, hi, err := parseRange(args)
+	if err != nil {
+		return field{}, err
+	}
+	min, err := time.ParseDuration(lo)
+	if err != nil {
+		return field{}, err
+	}
+	max, err := time.ParseDuration(hi)
+	if err != nil {
+		return field{}, err
+	}
+	if min <= 0 || max < min {
+		return field{}, fmt.Errorf("invalid range %q", args)
+	}
+
+	return field{generate: func(r *rand.Rand) string {
+		d := math.Exp(math.Log(float64(min)) + r.Float64()*(math.Log(float64(max))-math.Log(float64(min))))
+		return time.Duration(d).Round(time.Microsecond).String()
+	}}, nil
+}
+
+func newIPField(args string) (field, error) {
+	switch args {
+	case "", "v4":
+		return field{generate: func(r *rand.Rand) string {
+			return fmt.Sprintf("%d.%d.%d.%d", 1+r.Intn(223), r.Intn(256), r.Intn(256), 1+r.Intn(254))
+		}}, nil
+	case "private":
+		return field{generate: func(r *rand.Rand) string {
+			return fmt.Sprintf("10.%d.%d.%d", r.Intn(256), r.Intn(256), 1+r.Intn(254))
+		}}, nil
+	case "v6":
+		return field{generate: func(r *rand.Rand) string {
+			return fmt.Sprintf("2001:db8:%x:%x::%x", r.Intn(0x10000), r.Intn(0x10000), 1+r.Intn(0xffff))
+		}}, nil
+	}
+	return field{}, fmt.Errorf("unknown ip kind %q", args)
+}
+
+func uuid(r *rand.Rand) string {
+	return fmt.Sprintf("%08x-%04x-4%03x-%04x-%012x", r.Uint32(), r.Intn(1<<16), r.Intn(1<<12), 0x8000|r.Intn(1<<14), r.Int63n(1<<48))
+}
+
+// words contains a few entries that have to be quoted or escaped.
+var words = []string{
+	"request", "completed", "order", "payment", "user", "session", "cache", "miss", "retry", "upstream",
+	"timeout", "connection", "reset", "queue", "worker", "started", "stopped", "token", "expired", "invalid",
+	"database", "query", "slow", "record", "updated", "created", "deleted", "config", "reloaded", "shard",
+	`"quoted"`, "key=value", `C:\Temp`, "it's", "a,b",
+}
+
+func newWordsField(args string) (field, error) {
+	min, max := 3, 8
+	if args != "" {
+		lo, hi, err := parseRange(args)
+		if err != nil {
+			return field{}, err
+		}
+		if min, err = strconv.Atoi(lo); err != nil {
+			return field{}, err
+		}
+		if max, err = strconv.Atoi(hi); err != nil {
+			return field{}, err
+		}
+		if min < 1 || max < min {
+			return field{}, fmt.Errorf("invalid range %q", args)
+		}
+	}
+
+	return field{generate: func(r *rand.Rand) string {
+		n := min + r.Intn(max-min+1)
+		w := make([]string, n)
+		for i := range w {
+			w[i] = words[r.Intn(len(words))]
+		}
+		return langpack.Mix(r, strings.Join(w, " "))
+	}}, nil
+}
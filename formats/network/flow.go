@@ -0,0 +1,301 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kube-logging/log-generator/conf"
+)
+
+// captureWindow is the aggregation interval of flow log records.
+const captureWindow = time.Minute
+
+const (
+	accountID = "123456789012"
+	region    = "us-east-2"
+	vpcID     = "vpc-0a1b2c3d4e5f67890"
+)
+
+// iface is an elastic network interface of the VPC.
+type iface struct {
+	id       string
+	ip       string
+	subnet   string
+	instance string
+	az       string
+}
+
+type pool struct {
+	ifaces   []iface
+	external []string
+}
+
+// endpoints are generated once with a fixed seed, so every event draws from
+// the same addresses and interfaces.
+var endpoints = newPool(rand.New(rand.NewSource(1)), 64, 512)
+
+func newPool(r *rand.Rand, ifaces, external int) pool {
+	p := pool{}
+
+	azs := []string{"use2-az1", "use2-az2", "use2-az3"}
+	for i := 0; i < ifaces; i++ {
+		zone := r.Intn(len(azs))
+		p.ifaces = append(p.ifaces, iface{
+			id:       fmt.Sprintf("eni-%017x", r.Int63()),
+			ip:       fmt.Sprintf("10.0.%d.%d", zone*16+r.Intn(16), 4+r.Intn(250)),
+			subnet:   fmt.Sprintf("subnet-%017x", int64(zone)*0x1111111111+0xabcde00000),
+			instance: fmt.Sprintf("i-%017x", r.Int63()),
+			az:       azs[zone],
+		})
+	}
+	for i := 0; i < external; i++ {
+		p.external = append(p.external, fmt.Sprintf("%d.%d.%d.%d", 1+r.Intn(223), r.Intn(256), r.Intn(256), 1+r.Intn(254)))
+	}
+
+	return p
+}
+
+type service struct {
+	name     string
+	protocol int
+	port     int
+	// ingress services are reached from the internet
+	ingress bool
+	// internal services are reached from the VPC only
+	internal bool
+}
+
+var services = []service{
+	{name: "ssl", protocol: 6, port: 443, ingress: true},
+	{name: "ssl", protocol: 6, port: 443},
+	{name: "http", protocol: 6, port: 80, ingress: true},
+	{name: "dns", protocol: 17, port: 53},
+	{name: "ssh", protocol: 6, port: 22, ingress: true},
+	{name: "postgresql", protocol: 6, port: 5432, internal: true},
+	{name: "redis", protocol: 6, port: 6379, internal: true},
+	{name: "ntp", protocol: 17, port: 123},
+}
+
+var protocolNames = map[int]string{1: "icmp", 6: "tcp", 17: "udp"}
+
+// flow is a connection between two endpoints with the statistics of both
+// directions.
+type flow struct {
+	uid string
+
+	service   service
+	iface     iface
+	srcAddr   string
+	dstAddr   string
+	srcPort   int
+	dstPort   int
+	direction string
+
+	accepted    bool
+	established bool
+	duration    time.Duration
+	// time between the end of the flow and the end of the capture window
+	lag time.Duration
+
+	origPackets int
+	origBytes   int
+	respPackets int
+	respBytes   int
+}
+
+func (f *flow) severity() string {
+	if !f.accepted {
+		return "reject"
+	}
+	return "accept"
+}
+
+func newFlow(r *rand.Rand, s service) *flow {
+	local := endpoints.ifaces[r.Intn(len(endpoints.ifaces))]
+	f := &flow{
+		uid:         zeekUID(r),
+		service:     s,
+		iface:       local,
+		srcPort:     32768 + r.Intn(28232),
+		dstPort:     s.port,
+		accepted:    true,
+		established: true,
+		lag:         time.Duration(r.Int63n(int64(captureWindow / 2))),
+	}
+
+	switch {
+	case s.internal:
+		peer := endpoints.ifaces[r.Intn(len(endpoints.ifaces))]
+		f.srcAddr, f.dstAddr, f.direction = peer.ip, local.ip, "ingress"
+	case s.ingress && r.Intn(3) > 0:
+		f.srcAddr, f.dstAddr, f.direction = endpoints.external[r.Intn(len(endpoints.external))], local.ip, "ingress"
+	default:
+		f.srcAddr, f.dstAddr, f.direction = local.ip, endpoints.external[r.Intn(len(endpoints.external))], "egress"
+	}
+
+	// scanners hit ingress ports that security groups reject
+	if f.direction == "ingress" && !s.internal && r.Intn(10) == 0 {
+		f.accepted, f.established = false, false
+		f.origPackets = 1 + r.Intn(2)
+		f.origBytes = f.origPackets * (40 + r.Intn(24))
+		return f
+	}
+
+	// heavy-tailed transfer sizes, responses are larger than requests
+	f.origPackets = 1 + int(math.Exp(1.5+r.NormFloat64()))
+	f.respPackets = 1 + int(float64(f.origPackets)*(0.5+r.ExpFloat64()*2))
+	if s.protocol == 17 {
+		f.origPackets, f.respPackets = 1, 1
+	}
+	f.origBytes = f.origPackets * (52 + r.Intn(400))
+	f.respBytes = f.respPackets * (52 + r.Intn(1400))
+	f.duration = time.Duration(math.Exp(4+1.5*r.NormFloat64())) * time.Millisecond
+
+	if s.protocol == 6 && r.Intn(20) == 0 {
+		// no answer to the handshake
+		f.established = false
+		f.respPackets, f.respBytes = 0, 0
+		f.origPackets, f.origBytes = 1, 60
+		f.duration = 0
+	}
+
+	return f
+}
+
+func newFlowEvent(r *rand.Rand) event {
+	return newFlow(r, services[r.Intn(len(services))])
+}
+
+// tcpFlags returns the OR of the TCP flags seen in the flow.
+func (f *flow) tcpFlags() int {
+	switch {
+	case f.service.protocol != 6:
+		return 0
+	case !f.accepted || !f.established:
+		return 2
+	default:
+		return 19
+	}
+}
+
+func (f *flow) end(t time.Time) time.Time {
+	return t.Add(-f.lag)
+}
+
+func (f *flow) start(t time.Time) time.Time {
+	return f.end(t).Add(-f.duration)
+}
+
+func (f *flow) action() string {
+	if f.accepted {
+		return "ACCEPT"
+	}
+	return "REJECT"
+}
+
+// flowFields are the fields of VPC Flow Logs, records are written by the
+// interface of the flow for the originating direction.
+var flowFields = map[string]func(f *flow, t time.Time) string{
+	"account-id":          func(f *flow, t time.Time) string { return accountID },
+	"interface-id":        func(f *flow, t time.Time) string { return f.iface.id },
+	"srcaddr":             func(f *flow, t time.Time) string { return f.srcAddr },
+	"dstaddr":             func(f *flow, t time.Time) string { return f.dstAddr },
+	"srcport":             func(f *flow, t time.Time) string { return strconv.Itoa(f.srcPort) },
+	"dstport":             func(f *flow, t time.Time) string { return strconv.Itoa(f.dstPort) },
+	"protocol":            func(f *flow, t time.Time) string { return strconv.Itoa(f.service.protocol) },
+	"packets":             func(f *flow, t time.Time) string { return strconv.Itoa(f.origPackets) },
+	"bytes":               func(f *flow, t time.Time) string { return strconv.Itoa(f.origBytes) },
+	"start":               func(f *flow, t time.Time) string { return strconv.FormatInt(t.Add(-captureWindow).Unix(), 10) },
+	"end":                 func(f *flow, t time.Time) string { return strconv.FormatInt(t.Unix(), 10) },
+	"action":              func(f *flow, t time.Time) string { return f.action() },
+	"log-status":          func(f *flow, t time.Time) string { return "OK" },
+	"vpc-id":              func(f *flow, t time.Time) string { return vpcID },
+	"subnet-id":           func(f *flow, t time.Time) string { return f.iface.subnet },
+	"instance-id":         func(f *flow, t time.Time) string { return f.iface.instance },
+	"tcp-flags":           func(f *flow, t time.Time) string { return strconv.Itoa(f.tcpFlags()) },
+	"type":                func(f *flow, t time.Time) string { return "IPv4" },
+	"pkt-srcaddr":         func(f *flow, t time.Time) string { return f.srcAddr },
+	"pkt-dstaddr":         func(f *flow, t time.Time) string { return f.dstAddr },
+	"region":              func(f *flow, t time.Time) string { return region },
+	"az-id":               func(f *flow, t time.Time) string { return f.iface.az },
+	"sublocation-type":    func(f *flow, t time.Time) string { return "-" },
+	"sublocation-id":      func(f *flow, t time.Time) string { return "-" },
+	"pkt-src-aws-service": func(f *flow, t time.Time) string { return "-" },
+	"pkt-dst-aws-service": func(f *flow, t time.Time) string { return "-" },
+	"flow-direction":      func(f *flow, t time.Time) string { return f.direction },
+	"traffic-path":        func(f *flow, t time.Time) string { return f.trafficPath() },
+}
+
+// trafficPath returns the path egress traffic takes to the destination.
+func (f *flow) trafficPath() string {
+	switch {
+	case f.direction != "egress":
+		return "-"
+	case strings.HasPrefix(f.dstAddr, "10."):
+		return "1"
+	default:
+		return "8"
+	}
+}
+
+// vpcFlowFields are the fields of the default format of each version, in
+// the order of the flow log record.
+var vpcFlowFields = map[int][]string{
+	2: {"version", "account-id", "interface-id", "srcaddr", "dstaddr", "srcport", "dstport", "protocol", "packets", "bytes", "start", "end", "action", "log-status"},
+}
+
+func init() {
+	vpcFlowFields[3] = append(append([]string{}, vpcFlowFields[2]...), "vpc-id", "subnet-id", "instance-id", "tcp-flags", "type", "pkt-srcaddr", "pkt-dstaddr")
+	vpcFlowFields[4] = append(append([]string{}, vpcFlowFields[3]...), "region", "az-id", "sublocation-type", "sublocation-id")
+	vpcFlowFields[5] = append(append([]string{}, vpcFlowFields[4]...), "pkt-src-aws-service", "pkt-dst-aws-service", "flow-direction", "traffic-path")
+}
+
+func vpcFlowRenderer(version int) func(e event, t time.Time) string {
+	return func(e event, t time.Time) string {
+		f := e.(*flow)
+
+		values := make([]string, 0, len(vpcFlowFields[version]))
+		for _, name := range vpcFlowFields[version] {
+			if name == "version" {
+				values = append(values, strconv.Itoa(version))
+				continue
+			}
+			values = append(values, flowFields[name](f, t))
+		}
+		return strings.Join(values, " ")
+	}
+}
+
+// renderCSV renders the flow fields configured by network.csv_fields.
+func renderCSV(e event, t time.Time) string {
+	f := e.(*flow)
+
+	fields, err := csvFields()
+	if err != nil {
+		return err.Error()
+	}
+
+	values := make([]string, 0, len(fields))
+	for _, name := range fields {
+		values = append(values, flowFields[name](f, t))
+	}
+	return strings.Join(values, conf.Viper.GetString("network.csv_delimiter"))
+}
@@ -0,0 +1,155 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// generators create the content of the events of a format, and renderers
+// format it when the event is emitted.
+var generators = map[string]func(r *rand.Rand) event{
+	"vpcflow":    newFlowEvent,
+	"vpcflow.v3": newFlowEvent,
+	"vpcflow.v4": newFlowEvent,
+	"vpcflow.v5": newFlowEvent,
+	"csv":        newFlowEvent,
+	"zeek.conn":  newFlowEvent,
+	"zeek.dns":   newDNSEvent,
+	"zeek.http":  newHTTPEvent,
+	"cloudtrail": newCloudTrailEvent,
+}
+
+var renderers = map[string]func(e event, t time.Time) string{
+	"vpcflow":    vpcFlowRenderer(2),
+	"vpcflow.v3": vpcFlowRenderer(3),
+	"vpcflow.v4": vpcFlowRenderer(4),
+	"vpcflow.v5": vpcFlowRenderer(5),
+	"csv":        renderCSV,
+	"zeek.conn":  renderZeekConn,
+	"zeek.dns":   renderZeekDNS,
+	"zeek.http":  renderZeekHTTP,
+	"cloudtrail": renderCloudTrail,
+}
+
+func Formats() []string {
+	formats := make([]string, 0, len(generators))
+	for f := range generators {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+type event interface {
+	severity() string
+}
+
+type Network struct {
+	Format string
+
+	event    event
+	time     time.Time
+	isFramed bool
+}
+
+// NewNetwork returns a flow log record or a cloud audit record. Addresses,
+// ports and interfaces come from fixed pools, so records of the same
+// endpoints can be correlated. Without randomise, the same event is returned
+// every time.
+func NewNetwork(format string, randomise bool) (*Network, error) {
+	generate, ok := generators[format]
+	if !ok {
+		return nil, fmt.Errorf("could not find format %q", format)
+	}
+
+	if format == "csv" {
+		if _, err := csvFields(); err != nil {
+			return nil, err
+		}
+	}
+
+	seed := int64(1)
+	if randomise {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Network{
+		Format: format,
+		event:  generate(rand.New(rand.NewSource(seed))),
+	}, nil
+}
+
+func (n *Network) String() (string, float64) {
+	msg := renderers[n.Format](n.event, log.TimeOrNow(n.time))
+	return msg, float64(len(msg))
+}
+
+func (n *Network) Timestamp() time.Time {
+	return n.time
+}
+
+func (n *Network) SetTime(t time.Time) {
+	n.time = t
+}
+
+func (n *Network) IsFramed() bool {
+	return n.isFramed
+}
+
+func (n *Network) SetFramed(f bool) {
+	n.isFramed = f
+}
+
+func (n *Network) Labels() prometheus.Labels {
+	return prometheus.Labels{
+		"type":     "network." + n.Format,
+		"severity": n.event.severity(),
+	}
+}
+
+func pick(r *rand.Rand, items ...string) string {
+	return items[r.Intn(len(items))]
+}
+
+// csvFields returns the fields of the csv format from the [network] config
+// section.
+func csvFields() ([]string, error) {
+	var fields []string
+	for _, f := range strings.Split(conf.Viper.GetString("network.csv_fields"), ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if _, ok := flowFields[f]; !ok {
+			return nil, fmt.Errorf("unknown flow field %q", f)
+		}
+		fields = append(fields, f)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no flow fields configured")
+	}
+	return fields, nil
+}
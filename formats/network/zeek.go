@@ -0,0 +1,246 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package network
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	zeekUnset = "-"
+	zeekEmpty = "(empty)"
+)
+
+func zeekUID(r *rand.Rand) string {
+	const chars = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	uid := make([]byte, 18)
+	uid[0] = 'C'
+	for i := 1; i < len(uid); i++ {
+		uid[i] = chars[r.Intn(len(chars))]
+	}
+	return string(uid)
+}
+
+func zeekTime(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000)
+}
+
+func zeekBool(b bool) string {
+	if b {
+		return "T"
+	}
+	return "F"
+}
+
+// zeekLine joins the fields of a Zeek TSV log line.
+func zeekLine(fields ...string) string {
+	for i, f := range fields {
+		if f == "" {
+			fields[i] = zeekUnset
+		}
+	}
+	return strings.Join(fields, "\t")
+}
+
+func (f *flow) zeekID() []string {
+	return []string{f.srcAddr, strconv.Itoa(f.srcPort), f.dstAddr, strconv.Itoa(f.dstPort)}
+}
+
+// connState returns the Zeek connection state and history of the flow.
+func (f *flow) connState() (string, string) {
+	switch {
+	case !f.accepted:
+		return "REJ", "Sr"
+	case !f.established:
+		return "S0", "S"
+	case f.service.protocol == 17:
+		return "SF", "Dd"
+	default:
+		return "SF", "ShADadFf"
+	}
+}
+
+func renderZeekConn(e event, t time.Time) string {
+	f := e.(*flow)
+	state, history := f.connState()
+
+	service, duration, origBytes, respBytes := f.service.name, "", "", ""
+	if f.established {
+		duration = fmt.Sprintf("%.6f", f.duration.Seconds())
+		origBytes, respBytes = strconv.Itoa(f.payload(f.origBytes, f.origPackets)), strconv.Itoa(f.payload(f.respBytes, f.respPackets))
+	} else {
+		service = ""
+	}
+
+	fields := append([]string{zeekTime(f.start(t)), f.uid}, f.zeekID()...)
+	return zeekLine(append(fields,
+		protocolNames[f.service.protocol], service, duration, origBytes, respBytes, state,
+		zeekBool(strings.HasPrefix(f.srcAddr, "10.")), zeekBool(strings.HasPrefix(f.dstAddr, "10.")), "0", history,
+		strconv.Itoa(f.origPackets), strconv.Itoa(f.origBytes), strconv.Itoa(f.respPackets), strconv.Itoa(f.respBytes), zeekEmpty)...)
+}
+
+// payload returns the application bytes of IP bytes.
+func (f *flow) payload(ipBytes, packets int) int {
+	header := 40
+	if f.service.protocol == 17 {
+		header = 28
+	}
+	if p := ipBytes - packets*header; p > 0 {
+		return p
+	}
+	return 0
+}
+
+// dnsEvent is a DNS query of an internal client and its answer.
+type dnsEvent struct {
+	*flow
+	transID int
+	rtt     time.Duration
+	query   string
+	qtype   int
+	rcode   int
+	answers []string
+	ttls    []string
+}
+
+var dnsQueries = []string{"api.example.com", "s3.us-east-2.amazonaws.com", "sts.amazonaws.com", "registry-1.docker.io", "github.com", "updates.example.net", "x7f2kq9z.badcdn.example"}
+
+var dnsTypes = map[int]string{1: "A", 28: "AAAA", 5: "CNAME", 16: "TXT"}
+
+func newDNSEvent(r *rand.Rand) event {
+	f := newFlow(r, service{name: "dns", protocol: 17, port: 53})
+	f.dstAddr = "10.0.0.2"
+
+	e := &dnsEvent{
+		flow:    f,
+		transID: r.Intn(65536),
+		rtt:     time.Duration(200+r.Intn(40000)) * time.Microsecond,
+		query:   pick(r, dnsQueries...),
+		qtype:   []int{1, 1, 1, 28, 16}[r.Intn(5)],
+	}
+	if strings.Contains(e.query, "badcdn") || r.Intn(20) == 0 {
+		e.rcode = 3
+		return e
+	}
+
+	for i := 0; i < 1+r.Intn(3); i++ {
+		switch e.qtype {
+		case 28:
+			e.answers = append(e.answers, fmt.Sprintf("2600:1f16:%x:%x::%x", r.Intn(0xffff), r.Intn(0xffff), r.Intn(0xffff)))
+		case 16:
+			e.answers = append(e.answers, "TXT 36 v=spf1 include:_spf.example.com ~all")
+		default:
+			e.answers = append(e.answers, endpoints.external[r.Intn(len(endpoints.external))])
+		}
+		e.ttls = append(e.ttls, fmt.Sprintf("%d.000000", 30+r.Intn(3570)))
+	}
+	return e
+}
+
+func (e *dnsEvent) severity() string {
+	if e.rcode != 0 {
+		return "nxdomain"
+	}
+	return "noerror"
+}
+
+func renderZeekDNS(ev event, t time.Time) string {
+	e := ev.(*dnsEvent)
+
+	rcodeName := "NOERROR"
+	if e.rcode == 3 {
+		rcodeName = "NXDOMAIN"
+	}
+	answers, ttls := zeekUnset, zeekUnset
+	if len(e.answers) > 0 {
+		answers, ttls = strings.Join(e.answers, ","), strings.Join(e.ttls, ",")
+	}
+
+	fields := append([]string{zeekTime(e.start(t)), e.uid}, e.zeekID()...)
+	return zeekLine(append(fields,
+		"udp", strconv.Itoa(e.transID), fmt.Sprintf("%.6f", e.rtt.Seconds()), e.query, "1", "C_INTERNET",
+		strconv.Itoa(e.qtype), dnsTypes[e.qtype], strconv.Itoa(e.rcode), rcodeName,
+		"F", "F", "T", "T", "0", answers, ttls, "F")...)
+}
+
+// httpEvent is a plain HTTP request over a flow.
+type httpEvent struct {
+	*flow
+	method   string
+	host     string
+	uri      string
+	agent    string
+	status   int
+	reqLen   int
+	respLen  int
+	mimeType string
+}
+
+var httpStatus = map[int]string{200: "OK", 301: "Moved Permanently", 304: "Not Modified", 403: "Forbidden", 404: "Not Found", 500: "Internal Server Error"}
+
+func newHTTPEvent(r *rand.Rand) event {
+	f := newFlow(r, service{name: "http", protocol: 6, port: 80, ingress: true})
+	f.accepted, f.established = true, true
+	if f.origPackets < 3 {
+		f.origPackets, f.origBytes = 3, 3*(52+r.Intn(400))
+	}
+	if f.respPackets < 3 {
+		f.respPackets, f.respBytes = 3, 3*(52+r.Intn(1400))
+	}
+	if f.duration == 0 {
+		f.duration = time.Duration(1+r.Intn(500)) * time.Millisecond
+	}
+
+	e := &httpEvent{
+		flow:     f,
+		method:   pick(r, "GET", "GET", "GET", "POST", "HEAD"),
+		host:     pick(r, "example.com", "www.example.com", f.dstAddr),
+		uri:      pick(r, "/", "/index.html", "/api/v1/status", "/wp-login.php", "/.env", "/static/app.js?v=3"),
+		agent:    pick(r, "curl/8.5.0", "Mozilla/5.0 (X11; Linux x86_64; rv:128.0) Gecko/20100101 Firefox/128.0", "Go-http-client/1.1", "python-requests/2.31.0", "zgrab/0.x"),
+		status:   []int{200, 200, 200, 301, 304, 403, 404, 500}[r.Intn(8)],
+		mimeType: pick(r, "text/html", "application/json", "text/plain"),
+	}
+	if e.method == "POST" {
+		e.reqLen = f.payload(f.origBytes, f.origPackets)
+	}
+	if e.method != "HEAD" && e.status != 304 {
+		e.respLen = f.payload(f.respBytes, f.respPackets)
+	}
+	return e
+}
+
+func (e *httpEvent) severity() string {
+	return strconv.Itoa(e.status)
+}
+
+func renderZeekHTTP(ev event, t time.Time) string {
+	e := ev.(*httpEvent)
+
+	respMime := zeekUnset
+	if e.respLen > 0 {
+		respMime = e.mimeType
+	}
+
+	fields := append([]string{zeekTime(e.start(t)), e.uid}, e.zeekID()...)
+	return zeekLine(append(fields,
+		"1", e.method, e.host, e.uri, zeekUnset, "1.1", e.agent, zeekUnset,
+		strconv.Itoa(e.reqLen), strconv.Itoa(e.respLen), strconv.Itoa(e.status), httpStatus[e.status],
+		zeekUnset, zeekUnset, zeekEmpty, zeekUnset, zeekUnset, zeekUnset,
+		zeekUnset, zeekUnset, zeekUnset, zeekUnset, zeekUnset, respMime)...)
+}
@@ -0,0 +1,226 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"encoding/json"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// record is a log line read from a captured file, the content of the
+// container runtime wrapper of CRI and Docker lines.
+type record struct {
+	msg      string
+	time     time.Time
+	severity string
+	stamp    stamp
+}
+
+// stamp locates the timestamp of a message, so it can be rewritten in its
+// original format.
+type stamp struct {
+	start, end int
+	// layout of the time, or "s", "ms", "us" and "ns" for Unix times
+	layout string
+	// decimals of Unix times in seconds
+	decimals int
+}
+
+// withTime returns the message of r with its timestamp replaced by t.
+func (r record) withTime(t time.Time) string {
+	s := r.stamp
+	if s.end == 0 {
+		return r.msg
+	}
+
+	var value string
+	switch s.layout {
+	case "s":
+		value = strconv.FormatFloat(float64(t.UnixNano())/1e9, 'f', s.decimals, 64)
+	case "ms":
+		value = strconv.FormatInt(t.UnixMilli(), 10)
+	case "us":
+		value = strconv.FormatInt(t.UnixMicro(), 10)
+	case "ns":
+		value = strconv.FormatInt(t.UnixNano(), 10)
+	default:
+		value = t.Format(s.layout)
+	}
+	return r.msg[:s.start] + value + r.msg[s.end:]
+}
+
+var (
+	criLine     = regexp.MustCompile(`^(\S+) (stdout|stderr) ([PF]) ?(.*)$`)
+	levelWord   = regexp.MustCompile(`(?i)\b(trace|debug|info|notice|warn|warning|error|err|crit|critical|fatal|panic)\b`)
+	isoDateTime = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+)
+
+// timeFields are the fields holding the timestamps of NDJSON lines, in order
+// of preference.
+var timeFields = []string{"time", "timestamp", "@timestamp", "ts", "date"}
+
+// parseISO parses an RFC 3339 timestamp and returns the layout that formats
+// times the same way.
+func parseISO(s string) (time.Time, string, bool) {
+	m := isoDateTime.FindStringSubmatch(s)
+	if m == nil {
+		return time.Time{}, "", false
+	}
+	layout := "2006-01-02T15:04:05"
+	if m[1] != "" {
+		layout += "." + strings.Repeat("0", len(m[1])-1)
+	}
+	layout += "Z07:00"
+	t, err := time.Parse(layout, s)
+	return t, layout, err == nil
+}
+
+// leadingStamp finds a timestamp at the start of msg.
+func leadingStamp(msg string) (time.Time, stamp) {
+	token, _, _ := strings.Cut(msg, " ")
+	if t, layout, ok := parseISO(token); ok {
+		return t, stamp{start: 0, end: len(token), layout: layout}
+	}
+	return time.Time{}, stamp{}
+}
+
+// parseUnix parses a Unix time in seconds, milliseconds, microseconds or
+// nanoseconds, telling them apart by their magnitude.
+func parseUnix(raw string) (time.Time, stamp, bool) {
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || v <= 0 {
+		return time.Time{}, stamp{}, false
+	}
+	s := stamp{}
+	switch {
+	case v < 1e11:
+		s.layout = "s"
+		if _, frac, ok := strings.Cut(raw, "."); ok {
+			s.decimals = len(frac)
+		}
+		sec, frac := math.Modf(v)
+		return time.Unix(int64(sec), int64(frac*1e9)), s, true
+	case v < 1e14:
+		s.layout = "ms"
+		return time.UnixMilli(int64(v)), s, true
+	case v < 1e17:
+		s.layout = "us"
+		return time.UnixMicro(int64(v)), s, true
+	default:
+		s.layout = "ns"
+		return time.Unix(0, int64(v)), s, true
+	}
+}
+
+func severity(msg string) string {
+	m := levelWord.FindStringSubmatch(msg)
+	if m == nil {
+		return "unknown"
+	}
+	switch level := strings.ToLower(m[1]); level {
+	case "warn":
+		return "warning"
+	case "err":
+		return "error"
+	case "critical":
+		return "crit"
+	default:
+		return level
+	}
+}
+
+// parsePlain parses a plain line, its timestamp is the leading RFC 3339 one
+// if it has one.
+func parsePlain(line string) record {
+	t, s := leadingStamp(line)
+	return record{msg: line, time: t, severity: severity(line), stamp: s}
+}
+
+// parseNDJSON parses a JSON line with the timestamp in field, or in the first
+// of timeFields it has if field is empty.
+func parseNDJSON(line, field string) (record, bool) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return record{}, false
+	}
+
+	r := record{msg: line, severity: "unknown"}
+	for _, key := range []string{"level", "severity", "lvl"} {
+		var level string
+		if json.Unmarshal(fields[key], &level) == nil && level != "" {
+			r.severity = severity(level)
+			break
+		}
+	}
+
+	candidates := timeFields
+	if field != "" {
+		candidates = []string{field}
+	}
+	for _, key := range candidates {
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+		name, _ := json.Marshal(key)
+		start, end, ok := valueIndex(line, string(name), string(raw))
+		if !ok {
+			break
+		}
+
+		var value string
+		if json.Unmarshal(raw, &value) == nil {
+			if t, layout, ok := parseISO(value); ok {
+				r.time = t
+				r.stamp = stamp{start: start + 1, end: end - 1, layout: layout}
+			}
+		} else if t, s, ok := parseUnix(string(raw)); ok {
+			r.time = t
+			s.start, s.end = start, end
+			r.stamp = s
+		}
+		break
+	}
+	return r, true
+}
+
+// jsonSpace is the whitespace allowed around the colon of a JSON object.
+const jsonSpace = " \t\r\n"
+
+// valueIndex returns the offsets of raw in line, where it is the value of the
+// quoted key name.
+func valueIndex(line, name, raw string) (int, int, bool) {
+	for i := 0; ; {
+		n := strings.Index(line[i:], name)
+		if n < 0 {
+			return 0, 0, false
+		}
+		i += n + len(name)
+
+		rest := strings.TrimLeft(line[i:], jsonSpace)
+		if !strings.HasPrefix(rest, ":") {
+			continue
+		}
+		rest = strings.TrimLeft(rest[1:], jsonSpace)
+		if strings.HasPrefix(rest, raw) {
+			start := len(line) - len(rest)
+			return start, start + len(raw), true
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"errors"
+	"time"
+)
+
+// Play emits the lines of the source named name with the timing of the
+// source, until the file ends or stop is closed. With the preserve and scale
+// timings, lines without a timestamp are emitted right after the previous
+// one.
+func Play(name string, emit func(*Replay), stop <-chan struct{}) error {
+	s, err := sourceFor(name)
+	if err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+	var previous time.Time
+	for {
+		r, err := s.replay(name)
+		if errors.Is(err, ErrEnd) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if wait := s.wait(previous, r.record.time); wait > 0 {
+			timer.Reset(wait)
+			select {
+			case <-timer.C:
+			case <-stop:
+				return nil
+			}
+		} else {
+			select {
+			case <-stop:
+				return nil
+			default:
+			}
+		}
+		if !r.record.time.IsZero() {
+			previous = r.record.time
+		}
+
+		emit(r)
+	}
+}
+
+// wait returns the time to wait before emitting a line captured at t, after
+// one captured at previous.
+func (s *source) wait(previous, t time.Time) time.Duration {
+	switch s.config.Timing {
+	case TimingRate:
+		return time.Duration(float64(time.Second) / s.config.Rate)
+	case TimingPreserve, TimingScale:
+		if previous.IsZero() || t.IsZero() || t.Before(previous) {
+			return 0
+		}
+		gap := t.Sub(previous)
+		if s.config.Timing == TimingScale {
+			gap = time.Duration(float64(gap) / s.config.Factor)
+		}
+		return gap
+	}
+	return 0
+}
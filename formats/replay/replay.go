@@ -0,0 +1,360 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// Timings of the sources: tick emits a line per tick of the replay stream or
+// of an API request, the others are played on their own.
+const (
+	TimingTick     = "tick"
+	TimingPreserve = "preserve"
+	TimingScale    = "scale"
+	TimingRate     = "rate"
+)
+
+// ErrEnd is returned by a source that reached the end of its file without
+// looping.
+var ErrEnd = errors.New("end of replayed file")
+
+// SourceConfig is a captured log file of a [replay.sources.<name>] section.
+type SourceConfig struct {
+	Path string
+	// plain, cri, docker, ndjson or auto to detect it line by line
+	Format string
+	// field of the NDJSON timestamps, found by name if empty
+	TimestampField string
+	// RewriteTimestamps replaces the timestamps of the lines with the time
+	// they are emitted.
+	RewriteTimestamps bool
+	Timing            string
+	// Factor divides the inter-arrival times of the scale timing.
+	Factor float64
+	// Rate of the lines per second of the rate timing.
+	Rate float64
+	Loop bool
+}
+
+func SourceConfigFromViper(name string) SourceConfig {
+	key := "replay.sources." + name + "."
+	get := func(field, value string) string {
+		if conf.Viper.IsSet(key + field) {
+			return conf.Viper.GetString(key + field)
+		}
+		return value
+	}
+	return SourceConfig{
+		Path:              conf.Viper.GetString(key + "path"),
+		Format:            get("format", "auto"),
+		TimestampField:    conf.Viper.GetString(key + "timestamp_field"),
+		RewriteTimestamps: conf.Viper.GetBool(key + "rewrite_timestamps"),
+		Timing:            get("timing", TimingTick),
+		Factor:            conf.Viper.GetFloat64(key + "factor"),
+		Rate:              conf.Viper.GetFloat64(key + "rate"),
+		Loop:              conf.Viper.GetBool(key + "loop"),
+	}
+}
+
+func (c SourceConfig) validate() error {
+	if c.Path == "" {
+		return errors.New("path is required")
+	}
+	switch c.Format {
+	case "auto", "plain", "cri", "docker", "ndjson":
+	default:
+		return fmt.Errorf("unknown format %q", c.Format)
+	}
+	switch c.Timing {
+	case TimingTick, TimingPreserve:
+	case TimingScale:
+		if c.Factor <= 0 {
+			return fmt.Errorf("factor of the scale timing must be positive, got %v", c.Factor)
+		}
+	case TimingRate:
+		if c.Rate <= 0 {
+			return fmt.Errorf("rate of the rate timing must be positive, got %v", c.Rate)
+		}
+	default:
+		return fmt.Errorf("unknown timing %q", c.Timing)
+	}
+	return nil
+}
+
+func sourceNames(played bool) []string {
+	names := []string{}
+	for name := range conf.Viper.GetStringMap("replay.sources") {
+		timing := SourceConfigFromViper(name).Timing
+		if (timing != TimingTick) == played {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Formats returns the sources with the tick timing.
+func Formats() []string {
+	return sourceNames(false)
+}
+
+// Players returns the sources played with their own timing.
+func Players() []string {
+	return sourceNames(true)
+}
+
+// source reads the records of a captured file, shared by the streams and
+// requests replaying it.
+type source struct {
+	config SourceConfig
+
+	m      sync.Mutex
+	file   *os.File
+	reader *bufio.Reader
+	// container lines split in partial ones
+	partial string
+}
+
+var (
+	sourcesM sync.Mutex
+	sources  = map[string]*source{}
+)
+
+func sourceFor(name string) (*source, error) {
+	sourcesM.Lock()
+	defer sourcesM.Unlock()
+
+	if s, ok := sources[name]; ok {
+		return s, nil
+	}
+	if len(conf.Viper.GetStringMap("replay.sources."+name)) == 0 {
+		return nil, fmt.Errorf("could not find replay source %q", name)
+	}
+	s, err := openSource(SourceConfigFromViper(name))
+	if err != nil {
+		return nil, fmt.Errorf("replay source %q: %w", name, err)
+	}
+	sources[name] = s
+	return s, nil
+}
+
+func openSource(c SourceConfig) (*source, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+	f, err := os.Open(c.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &source{config: c, file: f, reader: bufio.NewReader(f)}, nil
+}
+
+// next returns the next record of the file, from its start again when it
+// loops.
+func (s *source) next() (record, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	rewound := false
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return record{}, err
+		}
+		if line == "" && err == io.EOF {
+			if !s.config.Loop || rewound {
+				return record{}, ErrEnd
+			}
+			if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+				return record{}, err
+			}
+			s.reader.Reset(s.file)
+			s.partial = ""
+			rewound = true
+			continue
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			continue
+		}
+		if r, ok := s.parse(line); ok {
+			return r, nil
+		}
+	}
+}
+
+// parse parses a line, it returns false for partial container lines that
+// continue on the next ones.
+func (s *source) parse(line string) (record, bool) {
+	format := s.config.Format
+	if format == "auto" {
+		format = detect(line)
+	}
+
+	switch format {
+	case "cri":
+		m := criLine.FindStringSubmatch(line)
+		if m == nil {
+			return parsePlain(line), true
+		}
+		if m[3] == "P" {
+			s.partial += m[4]
+			return record{}, false
+		}
+		return s.container(m[1], m[4]), true
+	case "docker":
+		var d dockerLine
+		if json.Unmarshal([]byte(line), &d) != nil {
+			return parsePlain(line), true
+		}
+		if !strings.HasSuffix(d.Log, "\n") {
+			s.partial += d.Log
+			return record{}, false
+		}
+		return s.container(d.Time, strings.TrimRight(d.Log, "\r\n")), true
+	case "ndjson":
+		if r, ok := parseNDJSON(line, s.config.TimestampField); ok {
+			return r, true
+		}
+	}
+	return parsePlain(line), true
+}
+
+type dockerLine struct {
+	Log  string `json:"log"`
+	Time string `json:"time"`
+}
+
+// container returns the record of the content of a container runtime line,
+// joined to its partial lines. The content is parsed as NDJSON or plain, but
+// timed by the runtime.
+func (s *source) container(runtimeTime, content string) record {
+	content = s.partial + content
+	s.partial = ""
+
+	r, ok := record{}, false
+	if strings.HasPrefix(content, "{") {
+		r, ok = parseNDJSON(content, s.config.TimestampField)
+	}
+	if !ok {
+		r = parsePlain(content)
+	}
+	if t, _, ok := parseISO(runtimeTime); ok {
+		r.time = t
+	}
+	return r
+}
+
+func detect(line string) string {
+	if strings.HasPrefix(line, "{") {
+		var fields map[string]json.RawMessage
+		if json.Unmarshal([]byte(line), &fields) != nil {
+			return "plain"
+		}
+		_, hasLog := fields["log"]
+		_, hasStream := fields["stream"]
+		if hasLog && hasStream {
+			return "docker"
+		}
+		return "ndjson"
+	}
+	if m := criLine.FindStringSubmatch(line); m != nil {
+		if _, _, ok := parseISO(m[1]); ok {
+			return "cri"
+		}
+	}
+	return "plain"
+}
+
+// Replay is a line of a captured file.
+type Replay struct {
+	Source string
+
+	record   record
+	rewrite  bool
+	time     time.Time
+	isFramed bool
+}
+
+// NewReplay returns the next line of the source named format. Replayed lines
+// are never randomised.
+func NewReplay(format string, _ bool) (*Replay, error) {
+	s, err := sourceFor(format)
+	if err != nil {
+		return nil, err
+	}
+	return s.replay(format)
+}
+
+func (s *source) replay(name string) (*Replay, error) {
+	r, err := s.next()
+	if err != nil {
+		return nil, err
+	}
+	return &Replay{Source: name, record: r, rewrite: s.config.RewriteTimestamps}, nil
+}
+
+// String returns the captured line, with its timestamp rewritten when the
+// source rewrites timestamps or the line was given a time.
+func (r *Replay) String() (string, float64) {
+	msg := r.record.msg
+	if r.rewrite || !r.time.IsZero() {
+		msg = r.record.withTime(log.TimeOrNow(r.time))
+	}
+	return msg, float64(len(msg))
+}
+
+// Timestamp returns the time the line was given, or its captured time unless the
+// source rewrites timestamps.
+func (r *Replay) Timestamp() time.Time {
+	if !r.time.IsZero() || r.rewrite {
+		return r.time
+	}
+	return r.record.time
+}
+
+func (r *Replay) SetTime(t time.Time) {
+	r.time = t
+}
+
+func (r *Replay) IsFramed() bool {
+	return r.isFramed
+}
+
+func (r *Replay) SetFramed(f bool) {
+	r.isFramed = f
+}
+
+func (r *Replay) Labels() prometheus.Labels {
+	return prometheus.Labels{
+		"type":     "replay",
+		"severity": r.record.severity,
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-logging/log-generator/conf"
+)
+
+func writeCapture(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "capture.log")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readAll(t *testing.T, s *source) []record {
+	t.Helper()
+	records := []record{}
+	for {
+		r, err := s.next()
+		if errors.Is(err, ErrEnd) {
+			return records
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, r)
+	}
+}
+
+func TestParse(t *testing.T) {
+	path := writeCapture(t,
+		`2026-03-01T10:00:00.000Z INFO started`,
+		`2026-03-01T10:00:01.500000000Z stdout P {"level":"error",`,
+		`2026-03-01T10:00:01.600000000Z stdout F "msg":"failed","ts":1772359200.25}`,
+		`{"log":"partial ","stream":"stderr","time":"2026-03-01T10:00:02Z"}`,
+		`{"log":"line\n","stream":"stderr","time":"2026-03-01T10:00:03Z"}`,
+		`{"@timestamp":"2026-03-01T10:00:04+01:00","severity":"WARN","msg":"slow"}`,
+		`no timestamp here`,
+	)
+	s, err := openSource(SourceConfig{Path: path, Format: "auto", Timing: TimingTick})
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := readAll(t, s)
+
+	expected := []struct {
+		msg, severity, time string
+	}{
+		{`2026-03-01T10:00:00.000Z INFO started`, "info", "2026-03-01T10:00:00Z"},
+		{`{"level":"error","msg":"failed","ts":1772359200.25}`, "error", "2026-03-01T10:00:01.6Z"},
+		{`partial line`, "unknown", "2026-03-01T10:00:03Z"},
+		{`{"@timestamp":"2026-03-01T10:00:04+01:00","severity":"WARN","msg":"slow"}`, "warning", "2026-03-01T09:00:04Z"},
+		{`no timestamp here`, "unknown", ""},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %d: %v", len(expected), len(records), records)
+	}
+	for i, e := range expected {
+		r := records[i]
+		if r.msg != e.msg || r.severity != e.severity {
+			t.Errorf("Expected %q (%s), got %q (%s)", e.msg, e.severity, r.msg, r.severity)
+		}
+		if got := ""; !r.time.IsZero() {
+			got = r.time.UTC().Format(time.RFC3339Nano)
+			if got != e.time {
+				t.Errorf("Expected time %s of %q, got %s", e.time, e.msg, got)
+			}
+		} else if e.time != "" {
+			t.Errorf("Expected time %s of %q, got none", e.time, e.msg)
+		}
+	}
+}
+
+func TestRewrite(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 30, 45, 123456789, time.UTC)
+	tests := []struct {
+		line, expected string
+	}{
+		{`2026-03-01T10:00:00.000Z INFO started`, `2026-10-19T12:30:45.123Z INFO started`},
+		{`2026-03-01T10:00:00+01:00 INFO started`, `2026-10-19T12:30:45Z INFO started`},
+		{`{"msg":"a","ts":1772359200.25}`, `{"msg":"a","ts":1792413045.12}`},
+		{`{"msg":"a","time": 1772359200000}`, `{"msg":"a","time": 1792413045123}`},
+		{`{"time":"2026-03-01T10:00:00.000000Z","msg":"time"}`, `{"time":"2026-10-19T12:30:45.123456Z","msg":"time"}`},
+		{`{"msg":"\"ts\"","ts" : 1772359200.25}`, `{"msg":"\"ts\"","ts" : 1792413045.12}`},
+		{`no timestamp here`, `no timestamp here`},
+	}
+	for _, test := range tests {
+		r, ok := parseNDJSON(test.line, "")
+		if !ok {
+			r = parsePlain(test.line)
+		}
+		if got := r.withTime(now); got != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, got)
+		}
+		if strings.HasPrefix(test.line, "{") && !json.Valid([]byte(r.withTime(now))) {
+			t.Errorf("Invalid JSON after rewriting %q", test.line)
+		}
+	}
+}
+
+func TestLoop(t *testing.T) {
+	path := writeCapture(t, "one", "two")
+	s, err := openSource(SourceConfig{Path: path, Format: "plain", Timing: TimingTick, Loop: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []string{"one", "two", "one", "two", "one"} {
+		r, err := s.next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if r.msg != expected {
+			t.Errorf("Expected line %d to be %q, got %q", i, expected, r.msg)
+		}
+	}
+}
+
+func TestPlay(t *testing.T) {
+	path := writeCapture(t,
+		`2026-03-01T10:00:00Z first`,
+		`2026-03-01T10:00:01Z second`,
+		`2026-03-01T10:00:02Z third`,
+	)
+	conf.Init()
+	conf.Viper.Set("replay.sources.scaled.path", path)
+	conf.Viper.Set("replay.sources.scaled.timing", TimingScale)
+	conf.Viper.Set("replay.sources.scaled.factor", 10)
+	conf.Viper.Set("replay.sources.scaled.rewrite_timestamps", true)
+
+	if p := Players(); len(p) != 1 || p[0] != "scaled" {
+		t.Fatalf("Expected the scaled player, got %v", p)
+	}
+	if f := Formats(); len(f) != 0 {
+		t.Errorf("Expected no tick sources, got %v", f)
+	}
+
+	lines := []string{}
+	began := time.Now()
+	err := Play("scaled", func(r *Replay) {
+		msg, _ := r.String()
+		lines = append(lines, msg)
+	}, make(chan struct{}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(began); d < 180*time.Millisecond || d > time.Second {
+		t.Errorf("Expected 2s of captured time played in 200ms, took %v", d)
+	}
+	if len(lines) != 3 || !strings.HasSuffix(lines[2], " third") || strings.HasPrefix(lines[2], "2026-03-01") {
+		t.Errorf("Expected the lines with rewritten timestamps, got %q", lines)
+	}
+}
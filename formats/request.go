@@ -0,0 +1,59 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package formats
+
+import (
+	"math/rand"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats/exceptions"
+	"github.com/kube-logging/log-generator/formats/golang"
+	"github.com/kube-logging/log-generator/formats/web"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// NewTracedRequest returns the events of a synthetic request in the order
+// they are logged: the lines of the application serving it, a stack trace for
+// failed requests and by chance, and the access log of the ingress in the
+// given web format. All of them carry the trace context of the request if
+// tracing is enabled.
+func NewTracedRequest(format string, i golang.GolangLogIntensity) ([]log.Log, error) {
+	data := web.RandomData()
+	ingress, err := log.NewLogTemplate(format, web.TemplateFS, data)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []log.Log
+	app := golang.NewGolangLogRandom(i)
+	for n := 1 + rand.Intn(max(conf.Viper.GetInt("tracing.app_lines"), 1)); n > 0; n-- {
+		line := golang.NewGolangLogRandom(i)
+		line.Application, line.Environment, line.Component = app.Application, app.Environment, app.Component
+		events = append(events, line)
+	}
+
+	if data.Code >= 500 || rand.Float64() < conf.Viper.GetFloat64("tracing.exception_probability") {
+		exception, err := exceptions.NewException("go", true)
+		if err != nil {
+			return nil, err
+		}
+		line := *app
+		line.Level = "error"
+		line.Stacktrace, _ = exception.String()
+		events = append(events, &line)
+	}
+
+	return append(events, ingress), nil
+}
@@ -0,0 +1,132 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cefFields maps event data to CEF extension keys. Fields without a
+// dictionary key use custom string or number fields with labels.
+var cefFields = []struct {
+	data  string
+	key   string
+	label string
+}{
+	{"SubjectUserName", "suser", ""},
+	{"SubjectDomainName", "sntdom", ""},
+	{"TargetUserName", "duser", ""},
+	{"TargetDomainName", "dntdom", ""},
+	{"IpAddress", "src", ""},
+	{"IpPort", "spt", ""},
+	{"WorkstationName", "shost", ""},
+	{"ProcessName", "sproc", ""},
+	{"ParentProcessName", "sproc", ""},
+	{"NewProcessName", "dproc", ""},
+	{"LogonType", "cn1", "LogonType"},
+	{"CommandLine", "cs1", "CommandLine"},
+	{"Status", "cs2", "Status"},
+	{"SubStatus", "cs3", "SubStatus"},
+	{"PrivilegeList", "cs4", "PrivilegeList"},
+	{"TargetLogonId", "cs5", "TargetLogonId"},
+}
+
+// cefHeaderEscaper escapes pipes and backslashes in CEF header fields.
+var cefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+
+// cefValueEscaper escapes extension values: backslashes, equal signs and line
+// breaks.
+var cefValueEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\r\n", `\n`, "\n", `\n`, "\r", `\r`)
+
+// renderCEF renders the event in ArcSight Common Event Format.
+func renderCEF(e *winEvent, t time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CEF:0|Microsoft|Microsoft Windows|10.0|%s|%s|%d|",
+		cefHeaderEscaper.Replace(providerName+":"+strconv.Itoa(e.id)), cefHeaderEscaper.Replace(e.title), e.cefSeverity())
+
+	outcome := "success"
+	if !e.success {
+		outcome = "failure"
+	}
+	fmt.Fprintf(&b, "rt=%d dvchost=%s externalId=%d cat=%s act=%s outcome=%s",
+		t.UnixMilli(), cefValueEscaper.Replace(e.computer), e.recordID, cefValueEscaper.Replace(e.taskName), e.action, outcome)
+
+	for _, f := range cefFields {
+		v := e.get(f.data)
+		if v == "" {
+			continue
+		}
+		if f.label != "" {
+			fmt.Fprintf(&b, " %sLabel=%s", f.key, f.label)
+		}
+		fmt.Fprintf(&b, " %s=%s", f.key, cefValueEscaper.Replace(v))
+	}
+
+	return b.String()
+}
+
+// leefFields maps event data to predefined LEEF attributes, other event data
+// is added as custom attributes.
+var leefFields = map[string]string{
+	"TargetUserName":    "usrName",
+	"TargetDomainName":  "domain",
+	"IpAddress":         "src",
+	"IpPort":            "srcPort",
+	"SubjectUserName":   "accountName",
+	"WorkstationName":   "identHostName",
+	"SubjectDomainName": "identGrpName",
+}
+
+const leefDelimiter = "^"
+
+var leefHeaderEscaper = strings.NewReplacer(`\`, `\\`, `|`, `\|`)
+
+var leefValueEscaper = strings.NewReplacer(`\`, `\\`, leefDelimiter, `\`+leefDelimiter, "\r\n", `\n`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+
+// renderLEEF renders the event in IBM QRadar Log Event Extended Format 2.0,
+// with a custom attribute delimiter.
+func renderLEEF(e *winEvent, t time.Time) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "LEEF:2.0|Microsoft|Windows|10.0|%d|%s|", e.id, leefHeaderEscaper.Replace(leefDelimiter))
+
+	attrs := []keyValue{
+		{"devTime", t.UTC().Format("Jan 02 2006 15:04:05.000 MST")},
+		{"devTimeFormat", "MMM dd yyyy HH:mm:ss.SSS z"},
+		{"cat", e.taskName},
+		{"sev", strconv.Itoa(e.cefSeverity())},
+		{"identSrc", e.computer},
+		{"EventRecordID", strconv.FormatInt(e.recordID, 10)},
+	}
+	for _, kv := range e.data {
+		if key, ok := leefFields[kv.key]; ok {
+			kv.key = key
+		}
+		attrs = append(attrs, kv)
+	}
+
+	for i, kv := range attrs {
+		if i > 0 {
+			b.WriteString(leefDelimiter)
+		}
+		b.WriteString(kv.key)
+		b.WriteString("=")
+		b.WriteString(leefValueEscaper.Replace(kv.value))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,143 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kube-logging/log-generator/formats/sysloglike"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// renderers format a Windows security event for a SIEM.
+var renderers = map[string]func(e *winEvent, t time.Time) string{
+	"cef":          renderCEF,
+	"leef":         renderLEEF,
+	"windows.xml":  renderWindowsXML,
+	"windows.json": renderWinlogbeat,
+}
+
+// syslogFormats are the syslog headers CEF and LEEF events can be delivered
+// with, e.g. "cef.rfc3164".
+var syslogFormats = []string{"rfc3164", "rfc5424"}
+
+func Formats() []string {
+	formats := make([]string, 0, len(renderers))
+	for f := range renderers {
+		formats = append(formats, f)
+		if !strings.HasPrefix(f, "windows.") {
+			for _, s := range syslogFormats {
+				formats = append(formats, f+"."+s)
+			}
+		}
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+type Security struct {
+	Format string
+
+	render   func(e *winEvent, t time.Time) string
+	syslog   string
+	event    *winEvent
+	time     time.Time
+	isFramed bool
+}
+
+// NewSecurity returns a Windows security event in the format of a SIEM, e.g.
+// ArcSight CEF or IBM LEEF, optionally with a syslog header. Without
+// randomise, the same event is returned every time.
+func NewSecurity(format string, randomise bool) (*Security, error) {
+	name, syslog := format, ""
+	if base, s, ok := strings.Cut(format, "."); ok && (base == "cef" || base == "leef") {
+		name, syslog = base, s
+	}
+
+	render, ok := renderers[name]
+	if !ok || (syslog != "" && !contains(syslogFormats, syslog)) {
+		return nil, fmt.Errorf("could not find format %q", format)
+	}
+
+	seed := int64(1)
+	if randomise {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Security{
+		Format: format,
+		render: render,
+		syslog: syslog,
+		event:  newWinEvent(rand.New(rand.NewSource(seed))),
+	}, nil
+}
+
+func (s *Security) String() (string, float64) {
+	now := log.TimeOrNow(s.time)
+	msg := s.render(s.event, now)
+
+	if s.syslog != "" {
+		l, err := sysloglike.NewSyslogMessage("syslog."+s.syslog, authPriv, s.event.syslogSeverity(), now,
+			s.event.computer, "Microsoft-Windows-Security-Auditing", s.event.processID, msg)
+		if err != nil {
+			return err.Error(), float64(len(err.Error()))
+		}
+		return l.String()
+	}
+
+	return msg, float64(len(msg))
+}
+
+func (s *Security) Timestamp() time.Time {
+	return s.time
+}
+
+func (s *Security) SetTime(t time.Time) {
+	s.time = t
+}
+
+func (s *Security) IsFramed() bool {
+	return s.isFramed
+}
+
+func (s *Security) SetFramed(f bool) {
+	s.isFramed = f
+}
+
+func (s *Security) Labels() prometheus.Labels {
+	return prometheus.Labels{
+		"type":     "security." + s.Format,
+		"severity": s.event.severity(),
+	}
+}
+
+func pick(r *rand.Rand, items ...string) string {
+	return items[r.Intn(len(items))]
+}
+
+func contains(items []string, s string) bool {
+	for _, i := range items {
+		if i == s {
+			return true
+		}
+	}
+	return false
+}
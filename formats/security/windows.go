@@ -0,0 +1,366 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package security
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	providerName = "Microsoft-Windows-Security-Auditing"
+	providerGUID = "{54849625-5478-4994-a5ba-3e3b0328c30d}"
+
+	keywordsSuccess = "0x8020000000000000"
+	keywordsFailure = "0x8010000000000000"
+
+	// syslog facility of security and authorization messages
+	authPriv = 10
+)
+
+type keyValue struct {
+	key   string
+	value string
+}
+
+// winEvent is a record of the Windows Security event log.
+type winEvent struct {
+	id       int
+	version  int
+	task     int
+	taskName string
+	title    string
+	action   string
+	category string
+	kind     string
+	success  bool
+
+	computer  string
+	recordID  int64
+	processID int
+	threadID  int
+	data      []keyValue
+}
+
+func (e *winEvent) severity() string {
+	if e.success {
+		return "audit_success"
+	}
+	return "audit_failure"
+}
+
+func (e *winEvent) syslogSeverity() int {
+	switch {
+	case e.id == 1102:
+		return 2
+	case !e.success:
+		return 4
+	default:
+		return 6
+	}
+}
+
+// cefSeverity returns the 0-10 severity of CEF and LEEF events.
+func (e *winEvent) cefSeverity() int {
+	switch e.id {
+	case 1102:
+		return 9
+	case 4740:
+		return 7
+	case 4625, 4720:
+		return 5
+	case 4672:
+		return 4
+	default:
+		return 3
+	}
+}
+
+func (e *winEvent) get(key string) string {
+	for _, kv := range e.data {
+		if kv.key == key {
+			return kv.value
+		}
+	}
+	return ""
+}
+
+type account struct {
+	name   string
+	domain string
+	sid    string
+}
+
+// accounts include names that need escaping in CEF, LEEF, XML and JSON.
+var accounts = []account{
+	{"jsmith", "CORP", "S-1-5-21-3623811015-3361044348-30300820-1104"},
+	{"Administrator", "CORP", "S-1-5-21-3623811015-3361044348-30300820-500"},
+	{"svc_backup", "CORP", "S-1-5-21-3623811015-3361044348-30300820-1187"},
+	{"o'brien", "CORP", "S-1-5-21-3623811015-3361044348-30300820-1211"},
+	{"müller.jürgen", "CORP", "S-1-5-21-3623811015-3361044348-30300820-1320"},
+	{"dev|ops", "CORP", "S-1-5-21-3623811015-3361044348-30300820-1402"},
+	{"WS-0142$", "CORP", "S-1-5-21-3623811015-3361044348-30300820-2101"},
+}
+
+var (
+	computers   = []string{"DC01.corp.example.com", "DC02.corp.example.com", "WS-0142.corp.example.com", "WS-0987.corp.example.com", "SRV-FILE01.corp.example.com", "SRV-SQL02.corp.example.com"}
+	workstation = []string{"WS-0142", "WS-0987", "LAPTOP-7F3K2", "-"}
+
+	// processes include command lines with quotes, pipes, equal signs and
+	// backslashes.
+	processes = []struct {
+		name    string
+		command string
+	}{
+		{`C:\Windows\System32\cmd.exe`, `cmd.exe /c "dir C:\Users\Public\*.ps1 | findstr /i invoke"`},
+		{`C:\Windows\System32\WindowsPowerShell\v1.0\powershell.exe`, `powershell.exe -NoProfile -ExecutionPolicy Bypass -EncodedCommand SQBFAFgAIAAoAE4AZQB3AC0ATwBiAGoAZQBjAHQAKQA=`},
+		{`C:\Windows\System32\net.exe`, `net user svc_backup P@ss=w0rd! /add /domain`},
+		{`C:\Windows\System32\reg.exe`, `reg query "HKLM\SOFTWARE\Microsoft\Windows NT\CurrentVersion\Winlogon" /v DefaultPassword`},
+		{`C:\Program Files\Google\Chrome\Application\chrome.exe`, `"C:\Program Files\Google\Chrome\Application\chrome.exe" --type=renderer --lang=en-US --field-trial-handle=1820,i,1729`},
+		{`C:\Windows\System32\schtasks.exe`, "schtasks.exe /create /tn \"Updater\" /tr \"C:\\ProgramData\\upd.exe\" /sc onlogon\r\n"},
+	}
+)
+
+func ipAddress(r *rand.Rand) string {
+	return fmt.Sprintf("10.%d.%d.%d", r.Intn(4), r.Intn(256), 1+r.Intn(254))
+}
+
+func logonID(r *rand.Rand) string {
+	return fmt.Sprintf("0x%x", 0x3e7+r.Intn(0xfffffff))
+}
+
+func subject(r *rand.Rand, a account) []keyValue {
+	return []keyValue{
+		{"SubjectUserSid", a.sid},
+		{"SubjectUserName", a.name},
+		{"SubjectDomainName", a.domain},
+		{"SubjectLogonId", logonID(r)},
+	}
+}
+
+var system = account{"DC01$", "CORP", "S-1-5-18"}
+
+func newWinEvent(r *rand.Rand) *winEvent {
+	target := accounts[r.Intn(len(accounts))]
+	e := &winEvent{
+		success:   true,
+		computer:  pick(r, computers...),
+		recordID:  1000000 + r.Int63n(90000000),
+		processID: 600 + r.Intn(200),
+		threadID:  1000 + r.Intn(9000),
+		kind:      "event",
+	}
+
+	switch n := r.Intn(100); {
+	case n < 35:
+		e.id, e.version, e.task, e.taskName = 4624, 2, 12544, "Logon"
+		e.title, e.action, e.category = "An account was successfully logged on.", "logged-in", "authentication"
+		e.data = append(subject(r, system),
+			keyValue{"TargetUserSid", target.sid},
+			keyValue{"TargetUserName", target.name},
+			keyValue{"TargetDomainName", target.domain},
+			keyValue{"TargetLogonId", logonID(r)},
+			keyValue{"LogonType", pick(r, "2", "3", "3", "3", "10")},
+			keyValue{"LogonProcessName", pick(r, "User32 ", "NtLmSsp ", "Kerberos")},
+			keyValue{"AuthenticationPackageName", pick(r, "Negotiate", "NTLM", "Kerberos")},
+			keyValue{"WorkstationName", pick(r, workstation...)},
+			keyValue{"LogonGuid", fmt.Sprintf("{%08x-%04x-%04x-%04x-%012x}", r.Uint32(), r.Intn(1<<16), r.Intn(1<<16), r.Intn(1<<16), r.Int63n(1<<48))},
+			keyValue{"ProcessId", fmt.Sprintf("0x%x", 0x200+r.Intn(0x400))},
+			keyValue{"ProcessName", `C:\Windows\System32\lsass.exe`},
+			keyValue{"IpAddress", ipAddress(r)},
+			keyValue{"IpPort", strconv.Itoa(49152 + r.Intn(16383))},
+		)
+	case n < 55:
+		e.id, e.version, e.task, e.taskName = 4625, 0, 12544, "Logon"
+		e.title, e.action, e.category = "An account failed to log on.", "logon-failed", "authentication"
+		e.success = false
+		e.data = append(subject(r, system),
+			keyValue{"TargetUserSid", "S-1-0-0"},
+			keyValue{"TargetUserName", target.name},
+			keyValue{"TargetDomainName", target.domain},
+			keyValue{"Status", "0xc000006d"},
+			keyValue{"FailureReason", "%%2313"},
+			keyValue{"SubStatus", pick(r, "0xc000006a", "0xc000006a", "0xc0000064", "0xc0000234")},
+			keyValue{"LogonType", pick(r, "3", "3", "10")},
+			keyValue{"LogonProcessName", "NtLmSsp "},
+			keyValue{"AuthenticationPackageName", "NTLM"},
+			keyValue{"WorkstationName", pick(r, workstation...)},
+			keyValue{"ProcessId", "0x0"},
+			keyValue{"ProcessName", "-"},
+			keyValue{"IpAddress", ipAddress(r)},
+			keyValue{"IpPort", strconv.Itoa(49152 + r.Intn(16383))},
+		)
+	case n < 75:
+		p := processes[r.Intn(len(processes))]
+		e.id, e.version, e.task, e.taskName = 4688, 2, 13312, "Process Creation"
+		e.title, e.action, e.category = "A new process has been created.", "created-process", "process"
+		e.data = append(subject(r, target),
+			keyValue{"NewProcessId", fmt.Sprintf("0x%x", 0x1000+r.Intn(0xf000))},
+			keyValue{"NewProcessName", p.name},
+			keyValue{"TokenElevationType", pick(r, "%%1936", "%%1937", "%%1938")},
+			keyValue{"ProcessId", fmt.Sprintf("0x%x", 0x1000+r.Intn(0xf000))},
+			keyValue{"CommandLine", p.command},
+			keyValue{"TargetUserSid", "S-1-0-0"},
+			keyValue{"TargetUserName", "-"},
+			keyValue{"TargetDomainName", "-"},
+			keyValue{"TargetLogonId", "0x0"},
+			keyValue{"ParentProcessName", pick(r, `C:\Windows\explorer.exe`, `C:\Windows\System32\cmd.exe`, `C:\Windows\System32\services.exe`)},
+			keyValue{"MandatoryLabel", "S-1-16-8192"},
+		)
+	case n < 85:
+		e.id, e.version, e.task, e.taskName = 4672, 0, 12548, "Special Logon"
+		e.title, e.action, e.category = "Special privileges assigned to new logon.", "logged-in-special", "iam"
+		e.data = append(subject(r, target),
+			keyValue{"PrivilegeList", "SeSecurityPrivilege\n\t\t\tSeBackupPrivilege\n\t\t\tSeRestorePrivilege\n\t\t\tSeDebugPrivilege"},
+		)
+	case n < 93:
+		e.id, e.version, e.task, e.taskName = 4634, 0, 12545, "Logoff"
+		e.title, e.action, e.category = "An account was logged off.", "logged-out", "authentication"
+		e.data = []keyValue{
+			{"TargetUserSid", target.sid},
+			{"TargetUserName", target.name},
+			{"TargetDomainName", target.domain},
+			{"TargetLogonId", logonID(r)},
+			{"LogonType", pick(r, "2", "3", "10")},
+		}
+	case n < 96:
+		e.id, e.version, e.task, e.taskName = 4740, 0, 13824, "User Account Management"
+		e.title, e.action, e.category = "A user account was locked out.", "locked-out-user-account", "iam"
+		e.data = append([]keyValue{
+			{"TargetUserName", target.name},
+			{"TargetDomainName", pick(r, workstation...)},
+			{"TargetSid", target.sid},
+		}, subject(r, system)...)
+	case n < 99:
+		e.id, e.version, e.task, e.taskName = 4720, 0, 13824, "User Account Management"
+		e.title, e.action, e.category = "A user account was created.", "added-user-account", "iam"
+		e.data = append([]keyValue{
+			{"TargetUserName", target.name},
+			{"TargetDomainName", target.domain},
+			{"TargetSid", target.sid},
+		}, append(subject(r, accounts[1]),
+			keyValue{"PrivilegeList", "-"},
+			keyValue{"SamAccountName", target.name},
+			keyValue{"DisplayName", "%%1793"},
+			keyValue{"UserPrincipalName", target.name + "@corp.example.com"},
+			keyValue{"UserAccountControl", "\n\t\t%%2080\n\t\t%%2082\n\t\t%%2084"},
+		)...)
+	default:
+		e.id, e.version, e.task, e.taskName = 1102, 0, 104, "Log clear"
+		e.title, e.action, e.category = "The audit log was cleared.", "audit-log-cleared", "configuration"
+		e.data = subject(r, accounts[1])
+	}
+
+	return e
+}
+
+func (e *winEvent) keywords() string {
+	if e.success {
+		return keywordsSuccess
+	}
+	return keywordsFailure
+}
+
+// message returns the rendered message of the event as shown by the Event
+// Viewer.
+func (e *winEvent) message() string {
+	var b strings.Builder
+	b.WriteString(e.title)
+	b.WriteString("\n")
+	for _, kv := range e.data {
+		fmt.Fprintf(&b, "\n\t%s:\t\t%s", kv.key, kv.value)
+	}
+	return b.String()
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	if err := xml.EscapeText(&b, []byte(s)); err != nil {
+		return s
+	}
+	return b.String()
+}
+
+func renderWindowsXML(e *winEvent, t time.Time) string {
+	var b strings.Builder
+	b.WriteString("<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'><System>")
+	fmt.Fprintf(&b, "<Provider Name='%s' Guid='%s'/>", providerName, providerGUID)
+	fmt.Fprintf(&b, "<EventID>%d</EventID><Version>%d</Version><Level>0</Level><Task>%d</Task><Opcode>0</Opcode>", e.id, e.version, e.task)
+	fmt.Fprintf(&b, "<Keywords>%s</Keywords>", e.keywords())
+	fmt.Fprintf(&b, "<TimeCreated SystemTime='%s'/>", t.UTC().Format("2006-01-02T15:04:05.0000000Z"))
+	fmt.Fprintf(&b, "<EventRecordID>%d</EventRecordID><Correlation/>", e.recordID)
+	fmt.Fprintf(&b, "<Execution ProcessID='%d' ThreadID='%d'/>", e.processID, e.threadID)
+	fmt.Fprintf(&b, "<Channel>Security</Channel><Computer>%s</Computer><Security/></System><EventData>", xmlEscape(e.computer))
+	for _, kv := range e.data {
+		fmt.Fprintf(&b, "<Data Name='%s'>%s</Data>", kv.key, xmlEscape(kv.value))
+	}
+	b.WriteString("</EventData></Event>")
+	return b.String()
+}
+
+// renderWinlogbeat renders the event as shipped by Winlogbeat.
+func renderWinlogbeat(e *winEvent, t time.Time) string {
+	eventData := make(map[string]string, len(e.data))
+	for _, kv := range e.data {
+		eventData[kv.key] = kv.value
+	}
+
+	outcome, keyword := "success", "Audit Success"
+	if !e.success {
+		outcome, keyword = "failure", "Audit Failure"
+	}
+
+	host := strings.ToLower(strings.SplitN(e.computer, ".", 2)[0])
+	out, err := json.Marshal(map[string]interface{}{
+		"@timestamp": t.UTC().Format("2006-01-02T15:04:05.000Z"),
+		"agent":      map[string]string{"type": "winlogbeat", "version": "8.14.3", "name": host},
+		"ecs":        map[string]string{"version": "8.0.0"},
+		"host":       map[string]string{"name": host, "hostname": host},
+		"log":        map[string]string{"level": "information"},
+		"message":    e.message(),
+		"event": map[string]interface{}{
+			"code":     strconv.Itoa(e.id),
+			"kind":     e.kind,
+			"provider": providerName,
+			"action":   e.action,
+			"category": []string{e.category},
+			"outcome":  outcome,
+			"created":  t.UTC().Format("2006-01-02T15:04:05.000Z"),
+		},
+		"winlog": map[string]interface{}{
+			"channel":       "Security",
+			"computer_name": e.computer,
+			"event_id":      strconv.Itoa(e.id),
+			"record_id":     e.recordID,
+			"provider_name": providerName,
+			"provider_guid": providerGUID,
+			"task":          e.taskName,
+			"opcode":        "Info",
+			"keywords":      []string{keyword},
+			"api":           "wineventlog",
+			"process":       map[string]interface{}{"pid": e.processID, "thread": map[string]int{"id": e.threadID}},
+			"event_data":    eventData,
+		},
+	})
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
@@ -0,0 +1,227 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"fmt"
+	"math/rand"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/kube-logging/log-generator/formats/langpack"
+)
+
+type caller struct {
+	class    string
+	function string
+	file     string
+	line     int
+}
+
+func (c caller) baseFile() string {
+	return path.Base(c.file)
+}
+
+// shortFile returns the package directory and file name, as zap does.
+func (c caller) shortFile() string {
+	return path.Join(path.Base(path.Dir(c.file)), path.Base(c.file))
+}
+
+func (c caller) shortFunction() string {
+	return c.function[strings.LastIndex(c.function, ".")+1:]
+}
+
+// event holds the content of a log line independently of its format.
+type event struct {
+	time     time.Time
+	level    string
+	msg      string
+	logger   string
+	hostname string
+	pid      int
+	thread   string
+	threadID int
+	caller   caller
+	stack    []caller
+	err      string
+	fields   object
+
+	// log4j2 events come from Java code
+	javaLogger string
+	javaStack  []caller
+	errType    string
+}
+
+var (
+	levels = []struct {
+		level  string
+		weight int
+	}{{"debug", 10}, {"info", 70}, {"warn", 15}, {"error", 5}}
+
+	messages = map[string][]string{
+		"debug": {"cache lookup", "acquired connection from pool", "resolved service endpoint", "request headers parsed"},
+		"info":  {"request completed", "order created", "payment authorized", "user logged in", "starting worker", "configuration reloaded"},
+		"warn":  {"slow query detected", "retrying request", "cache miss rate is high", "deprecated API version used"},
+		"error": {"failed to process order", "payment declined", "database query failed", "upstream request failed"},
+	}
+
+	failures = []struct {
+		msg      string
+		javaType string
+	}{
+		{"context deadline exceeded", "java.util.concurrent.TimeoutException"},
+		{"dial tcp 10.0.12.4:5432: connect: connection refused", "java.net.ConnectException"},
+		{"sql: no rows in result set", "javax.persistence.NoResultException"},
+		{"invalid character 'x' looking for beginning of value", "com.fasterxml.jackson.core.JsonParseException"},
+		{"insufficient funds", "com.example.shop.payment.PaymentDeclinedException"},
+	}
+
+	components = []string{"orders", "payment", "inventory", "gateway", "auth"}
+
+	goCallers = []caller{
+		{function: "github.com/example/shop/orders.(*Service).Create", file: "/app/orders/service.go"},
+		{function: "github.com/example/shop/orders.(*Handler).ServeHTTP", file: "/app/orders/handler.go"},
+		{function: "github.com/example/shop/payment.(*Client).Authorize", file: "/app/payment/client.go"},
+		{function: "github.com/example/shop/inventory.(*Repository).Reserve", file: "/app/inventory/repository.go"},
+		{function: "github.com/example/shop/internal/server.(*Server).handle", file: "/app/internal/server/server.go"},
+		{function: "main.main", file: "/app/cmd/shop/main.go"},
+	}
+
+	javaCallers = []caller{
+		{class: "com.example.shop.orders.OrderService", function: "create", file: "OrderService.java"},
+		{class: "com.example.shop.orders.OrderController", function: "post", file: "OrderController.java"},
+		{class: "com.example.shop.payment.PaymentClient", function: "authorize", file: "PaymentClient.java"},
+		{class: "org.springframework.web.servlet.FrameworkServlet", function: "service", file: "FrameworkServlet.java"},
+		{class: "java.lang.Thread", function: "run", file: "Thread.java"},
+	}
+)
+
+type fieldGenerator func(r *rand.Rand) interface{}
+
+var (
+	fieldNames = []string{"request_id", "user_id", "order_id", "duration_ms", "status", "method", "path", "remote_addr", "attempt", "cache_hit", "tenant", "bytes"}
+
+	fieldGenerators = map[string]fieldGenerator{
+		"request_id": func(r *rand.Rand) interface{} {
+			return fmt.Sprintf("%08x-%04x-4%03x-%04x-%012x", r.Uint32(), r.Intn(1<<16), r.Intn(1<<12), 0x8000|r.Intn(1<<14), r.Int63n(1<<48))
+		},
+		"user_id":     func(r *rand.Rand) interface{} { return 1000 + r.Intn(100000) },
+		"order_id":    func(r *rand.Rand) interface{} { return fmt.Sprintf("ord_%d", r.Intn(1000000)) },
+		"duration_ms": func(r *rand.Rand) interface{} { return float64(r.Intn(500000)) / 1000 },
+		"status":      func(r *rand.Rand) interface{} { return []int{200, 201, 204, 400, 404, 500}[r.Intn(6)] },
+		"method":      func(r *rand.Rand) interface{} { return []string{"GET", "POST", "PUT", "DELETE"}[r.Intn(4)] },
+		"path": func(r *rand.Rand) interface{} {
+			return []string{"/api/orders", "/api/cart", "/api/users/me", "/healthz"}[r.Intn(4)]
+		},
+		"remote_addr": func(r *rand.Rand) interface{} {
+			return fmt.Sprintf("10.%d.%d.%d", r.Intn(256), r.Intn(256), 1+r.Intn(254))
+		},
+		"attempt":   func(r *rand.Rand) interface{} { return 1 + r.Intn(5) },
+		"cache_hit": func(r *rand.Rand) interface{} { return r.Intn(2) == 0 },
+		"tenant": func(r *rand.Rand) interface{} {
+			return langpack.Mix(r, []string{"acme", "globex", "initech", "umbrella"}[r.Intn(4)])
+		},
+		"bytes": func(r *rand.Rand) interface{} { return r.Intn(1 << 20) },
+	}
+
+	groupNames = []string{"http", "db", "user", "payment", "k8s", "peer"}
+)
+
+// newFields returns count random fields, the last of them a group nested
+// depth levels deep.
+func newFields(r *rand.Rand, count int, depth int) object {
+	o := object{}
+	for _, i := range r.Perm(len(fieldNames)) {
+		if len(o) >= count || depth > 0 && len(o) == count-1 {
+			break
+		}
+		name := fieldNames[i]
+		o = o.with(name, fieldGenerators[name](r))
+	}
+
+	if depth > 0 && count > 0 {
+		o = o.with(groupNames[r.Intn(len(groupNames))], newFields(r, 3, depth-1))
+	}
+	return o
+}
+
+func newEvent(r *rand.Rand, extraFields int, nestingDepth int) *event {
+	e := &event{
+		level:    pickLevel(r),
+		logger:   components[r.Intn(len(components))],
+		hostname: fmt.Sprintf("%s-%x-%x", components[r.Intn(len(components))], r.Intn(1<<24), r.Intn(1<<20)),
+		pid:      1 + r.Intn(32768),
+		thread:   []string{"main", "http-nio-8080-exec-1", "http-nio-8080-exec-7", "scheduling-1"}[r.Intn(4)],
+		threadID: 1 + r.Intn(200),
+		fields:   newFields(r, extraFields, nestingDepth),
+	}
+	e.msg = langpack.Mix(r, messages[e.level][r.Intn(len(messages[e.level]))])
+
+	for i := 2 + r.Intn(5); i > 0; i-- {
+		c := goCallers[r.Intn(len(goCallers))]
+		c.line = 10 + r.Intn(400)
+		e.stack = append(e.stack, c)
+
+		j := javaCallers[r.Intn(len(javaCallers))]
+		j.line = 10 + r.Intn(400)
+		e.javaStack = append(e.javaStack, j)
+	}
+	e.caller = e.stack[0]
+	e.javaLogger = e.javaStack[0].class
+
+	if e.level == "error" {
+		err := failures[r.Intn(len(failures))]
+		e.err = err.msg
+		e.errType = err.javaType
+	}
+
+	return e
+}
+
+func pickLevel(r *rand.Rand) string {
+	total := 0
+	for _, l := range levels {
+		total += l.weight
+	}
+
+	n := r.Intn(total)
+	for _, l := range levels {
+		if n < l.weight {
+			return l.level
+		}
+		n -= l.weight
+	}
+	return levels[len(levels)-1].level
+}
+
+// goStacktrace returns the stack in the format of runtime/debug.Stack, as zap
+// adds it to error logs.
+func (e *event) goStacktrace() string {
+	lines := []string{}
+	for _, c := range e.stack {
+		lines = append(lines, c.function, fmt.Sprintf("\t%s:%d", c.file, c.line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (e *event) nodeStacktrace() string {
+	lines := []string{"Error: " + e.err}
+	for _, c := range e.stack {
+		file := strings.TrimSuffix(strings.Replace(c.file, "/app/", "/app/src/", 1), ".go") + ".js"
+		lines = append(lines, fmt.Sprintf("    at %s (%s:%d:%d)", c.shortFunction(), file, c.line, 5+c.line%40))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,65 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+type field struct {
+	key   string
+	value interface{}
+}
+
+// object is a JSON object that keeps the order of its keys, as the logging
+// libraries write them in a fixed order.
+type object []field
+
+func (o object) with(key string, value interface{}) object {
+	return append(o, field{key: key, value: value})
+}
+
+// sorted returns the object with its keys in alphabetical order, as logrus
+// writes them.
+func (o object) sorted() object {
+	s := append(object{}, o...)
+	sort.SliceStable(s, func(i, j int) bool { return s[i].key < s[j].key })
+	return s
+}
+
+func (o object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
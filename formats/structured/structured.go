@@ -0,0 +1,253 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package structured
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// encoders render an event with the key names, time encoding and level
+// representation of a logging library.
+var encoders = map[string]func(e *event) object{
+	"logrus":  logrus,
+	"zap":     zap,
+	"zerolog": zerolog,
+	"slog":    slog,
+	"log4j2":  log4j2,
+	"bunyan":  bunyan,
+}
+
+func Formats() []string {
+	formats := make([]string, 0, len(encoders))
+	for f := range encoders {
+		formats = append(formats, f)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+type Structured struct {
+	Format string
+
+	event    *event
+	time     time.Time
+	isFramed bool
+}
+
+// NewStructured returns a structured log line of a logging library. The number
+// of extra fields and their nesting depth are read from the [structured]
+// config section. Without randomise, the same event is returned every time.
+func NewStructured(format string, randomise bool) (*Structured, error) {
+	if _, ok := encoders[format]; !ok {
+		return nil, fmt.Errorf("could not find format %q", format)
+	}
+
+	seed := int64(1)
+	if randomise {
+		seed = time.Now().UnixNano()
+	}
+
+	return &Structured{
+		Format: format,
+		event: newEvent(rand.New(rand.NewSource(seed)),
+			conf.Viper.GetInt("structured.extra_fields"), conf.Viper.GetInt("structured.nesting_depth")),
+	}, nil
+}
+
+func (s *Structured) String() (string, float64) {
+	s.event.time = log.TimeOrNow(s.time)
+
+	out, err := json.Marshal(encoders[s.Format](s.event))
+	if err != nil {
+		return err.Error(), float64(len(err.Error()))
+	}
+
+	return string(out), float64(len(out))
+}
+
+func (s *Structured) Timestamp() time.Time {
+	return s.time
+}
+
+func (s *Structured) SetTime(t time.Time) {
+	s.time = t
+}
+
+func (s *Structured) IsFramed() bool {
+	return s.isFramed
+}
+
+func (s *Structured) SetFramed(f bool) {
+	s.isFramed = f
+}
+
+func (s *Structured) Labels() prometheus.Labels {
+	return prometheus.Labels{
+		"type":     "structured." + s.Format,
+		"severity": s.event.level,
+	}
+}
+
+func logrus(e *event) object {
+	level := e.level
+	if level == "warn" {
+		level = "warning"
+	}
+
+	o := e.fields.
+		with("time", e.time.Format(time.RFC3339)).
+		with("level", level).
+		with("msg", e.msg).
+		with("func", e.caller.function).
+		with("file", fmt.Sprintf("%s:%d", e.caller.file, e.caller.line))
+	if e.err != "" {
+		o = o.with("error", e.err)
+	}
+
+	// logrus writes a map, so the keys are sorted
+	return o.sorted()
+}
+
+func zap(e *event) object {
+	o := object{}.
+		with("level", e.level).
+		with("ts", float64(e.time.UnixMicro())/1e6).
+		with("logger", e.logger).
+		with("caller", fmt.Sprintf("%s:%d", e.caller.shortFile(), e.caller.line)).
+		with("msg", e.msg)
+	o = append(o, e.fields...)
+	if e.err != "" {
+		o = o.with("error", e.err).with("stacktrace", e.goStacktrace())
+	}
+	return o
+}
+
+func zerolog(e *event) object {
+	o := object{}.
+		with("level", e.level)
+	o = append(o, e.fields...)
+	if e.err != "" {
+		stack := []object{}
+		for _, c := range e.stack {
+			stack = append(stack, object{}.
+				with("func", c.shortFunction()).
+				with("line", fmt.Sprint(c.line)).
+				with("source", c.baseFile()))
+		}
+		o = o.with("stack", stack).with("error", e.err)
+	}
+	return o.
+		with("time", e.time.UTC().Format(time.RFC3339)).
+		with("caller", fmt.Sprintf("%s:%d", e.caller.file, e.caller.line)).
+		with("message", e.msg)
+}
+
+var slogLevels = map[string]string{"debug": "DEBUG", "info": "INFO", "warn": "WARN", "error": "ERROR"}
+
+func slog(e *event) object {
+	o := object{}.
+		with("time", e.time.Format(time.RFC3339Nano)).
+		with("level", slogLevels[e.level]).
+		with("source", object{}.
+			with("function", e.caller.function).
+			with("file", e.caller.file).
+			with("line", e.caller.line)).
+		with("msg", e.msg)
+	o = append(o, e.fields...)
+	if e.err != "" {
+		o = o.with("err", e.err)
+	}
+	return o
+}
+
+func log4j2(e *event) object {
+	o := object{}.
+		with("instant", object{}.
+			with("epochSecond", e.time.Unix()).
+			with("nanoOfSecond", e.time.Nanosecond())).
+		with("thread", e.thread).
+		with("level", slogLevels[e.level]).
+		with("loggerName", e.javaLogger).
+		with("message", e.msg)
+
+	if e.err != "" {
+		frames := []object{}
+		for _, c := range e.javaStack {
+			frames = append(frames, object{}.
+				with("class", c.class).
+				with("method", c.function).
+				with("file", c.file).
+				with("line", c.line).
+				with("exact", false).
+				with("location", "classes/").
+				with("version", "?"))
+		}
+		o = o.with("thrown", object{}.
+			with("commonElementCount", 0).
+			with("localizedMessage", e.err).
+			with("message", e.err).
+			with("name", e.errType).
+			with("extendedStackTrace", frames))
+	}
+
+	context := object{}
+	for _, f := range e.fields {
+		// the context map only holds strings
+		if _, nested := f.value.(object); !nested {
+			context = context.with(f.key, fmt.Sprint(f.value))
+		}
+	}
+
+	return o.
+		with("endOfBatch", false).
+		with("loggerFqcn", "org.apache.logging.log4j.spi.AbstractLogger").
+		with("contextMap", context).
+		with("threadId", e.threadID).
+		with("threadPriority", 5)
+}
+
+var bunyanLevels = map[string]int{"debug": 20, "info": 30, "warn": 40, "error": 50}
+
+func bunyan(e *event) object {
+	o := object{}.
+		with("name", e.logger).
+		with("hostname", e.hostname).
+		with("pid", e.pid).
+		with("level", bunyanLevels[e.level])
+	o = append(o, e.fields...)
+	if e.err != "" {
+		o = o.with("err", object{}.
+			with("message", e.err).
+			with("name", "Error").
+			with("stack", e.nodeStacktrace()))
+	}
+	return o.
+		with("msg", e.msg).
+		with("src", object{}.
+			with("file", e.caller.file).
+			with("line", e.caller.line).
+			with("func", e.caller.shortFunction())).
+		with("time", e.time.UTC().Format("2006-01-02T15:04:05.000Z")).
+		with("v", 0)
+}
@@ -0,0 +1,308 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sysloglike
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Pallinder/go-randomdata"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats/tracing"
+)
+
+// nilValue is the RFC 5424 NILVALUE of header fields and structured data.
+const nilValue = "-"
+
+// bom is the byte order mark that starts RFC 5424 messages encoded in UTF-8.
+const bom = "\ufeff"
+
+// SDParam is a PARAM-NAME="PARAM-VALUE" pair of an SD-ELEMENT.
+type SDParam struct {
+	Name  string
+	Value string
+}
+
+// SDElement is an RFC 5424 structured data element.
+type SDElement struct {
+	ID     string
+	Params []SDParam
+}
+
+// sdEscaper escapes the characters that have to be escaped in PARAM-VALUEs.
+var sdEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+func (e SDElement) String() string {
+	var b strings.Builder
+	b.WriteString("[" + e.ID)
+	for _, p := range e.Params {
+		fmt.Fprintf(&b, ` %s="%s"`, p.Name, sdEscaper.Replace(p.Value))
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// header fields of RFC 5424 messages that can be NILVALUE
+const (
+	nilTimestamp = 1 << iota
+	nilHost
+	nilAppName
+	nilProcID
+	nilMsgID
+)
+
+var facilityNames = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11, "ntp": 12, "security": 13, "console": 14, "solaris-cron": 15,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+var severityNames = map[string]int{
+	"emerg": 0, "alert": 1, "crit": 2, "err": 3, "warning": 4, "notice": 5, "info": 6, "debug": 7,
+}
+
+// weighted is a distribution of the codes of facilities or severities.
+type weighted struct {
+	codes   []int
+	weights []int
+	total   int
+}
+
+// parseWeighted parses a comma separated list of name=weight pairs, where
+// name is one of names or its numeric code below limit.
+func parseWeighted(spec string, names map[string]int, limit int) (weighted, error) {
+	var w weighted
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, weight := item, 1
+		if n, v, ok := strings.Cut(item, "="); ok {
+			var err error
+			if weight, err = strconv.Atoi(strings.TrimSpace(v)); err != nil || weight < 0 {
+				return w, fmt.Errorf("invalid weight of %q", n)
+			}
+			name = strings.TrimSpace(n)
+		}
+		code, ok := names[name]
+		if !ok {
+			var err error
+			if code, err = strconv.Atoi(name); err != nil || code < 0 || code >= limit {
+				return w, fmt.Errorf("unknown name %q", name)
+			}
+		}
+
+		w.codes = append(w.codes, code)
+		w.weights = append(w.weights, weight)
+		w.total += weight
+	}
+
+	if w.total == 0 {
+		return w, fmt.Errorf("no values with positive weight in %q", spec)
+	}
+	return w, nil
+}
+
+func (w weighted) pick() int {
+	n := randomdata.Number(w.total)
+	for i, weight := range w.weights {
+		if n < weight {
+			return w.codes[i]
+		}
+		n -= weight
+	}
+	return w.codes[len(w.codes)-1]
+}
+
+// profile is the distribution of the random syslog messages, read from the
+// [sysloglike] config section.
+type profile struct {
+	facilities     weighted
+	severities     weighted
+	msgIDs         []string
+	sdElements     int
+	bomProbability float64
+	nilProbability float64
+}
+
+var (
+	randomProfile    *profile
+	randomProfileErr error
+	randomProfileMu  sync.Once
+)
+
+// LoadProfile reads the profile of the random messages from the [sysloglike]
+// config section, once, so an invalid config is reported at startup.
+func LoadProfile() error {
+	_, err := syslogProfile()
+	return err
+}
+
+func syslogProfile() (*profile, error) {
+	randomProfileMu.Do(func() {
+		randomProfile, randomProfileErr = profileFromConfig()
+	})
+	return randomProfile, randomProfileErr
+}
+
+func profileFromConfig() (*profile, error) {
+	p := &profile{
+		sdElements:     conf.Viper.GetInt("sysloglike.sd_elements"),
+		bomProbability: conf.Viper.GetFloat64("sysloglike.bom_probability"),
+		nilProbability: conf.Viper.GetFloat64("sysloglike.nil_probability"),
+	}
+
+	var err error
+	if p.facilities, err = parseWeighted(conf.Viper.GetString("sysloglike.facilities"), facilityNames, 24); err != nil {
+		return nil, fmt.Errorf("sysloglike.facilities: %w", err)
+	}
+	if p.severities, err = parseWeighted(conf.Viper.GetString("sysloglike.severities"), severityNames, 8); err != nil {
+		return nil, fmt.Errorf("sysloglike.severities: %w", err)
+	}
+
+	for _, id := range strings.Split(conf.Viper.GetString("sysloglike.msgids"), ",") {
+		id = strings.TrimSpace(id)
+		if id == "" {
+			continue
+		}
+		if len(id) > 32 || strings.IndexFunc(id, func(r rune) bool { return r < 33 || r > 126 }) >= 0 {
+			return nil, fmt.Errorf("sysloglike.msgids: invalid MSGID %q", id)
+		}
+		p.msgIDs = append(p.msgIDs, id)
+	}
+	if len(p.msgIDs) == 0 {
+		p.msgIDs = []string{nilValue}
+	}
+
+	if p.sdElements < 0 || p.sdElements > len(sdCatalog) {
+		return nil, fmt.Errorf("sysloglike.sd_elements must be between 0 and %d", len(sdCatalog))
+	}
+	return p, nil
+}
+
+// nilFields returns the header fields of a message that are NILVALUE.
+func (p *profile) nilFields() int {
+	fields := 0
+	for f := nilTimestamp; f <= nilMsgID; f <<= 1 {
+		if randomdata.Decimal(0, 1) < p.nilProbability {
+			fields |= f
+		}
+	}
+	return fields
+}
+
+// structuredData returns up to sdElements elements with distinct SD-IDs.
+func (p *profile) structuredData(seq int) []SDElement {
+	n := 0
+	if p.sdElements > 0 {
+		n = randomdata.Number(p.sdElements + 1)
+	}
+
+	var elements []SDElement
+	for _, i := range randomPermutation(len(sdCatalog))[:n] {
+		elements = append(elements, sdCatalog[i](seq))
+	}
+	return elements
+}
+
+func randomPermutation(n int) []int {
+	p := make([]int, n)
+	for i := range p {
+		j := randomdata.Number(i + 1)
+		p[i], p[j] = p[j], i
+	}
+	return p
+}
+
+// sdValues contain the characters that have to be escaped in PARAM-VALUEs.
+var sdValues = []string{
+	`C:\Program Files\App\app.exe`,
+	`user said "hello"`,
+	`[error] in module]`,
+	`/search?q=a&filter=[x]`,
+	`DOMAIN\svc_backup`,
+	`trailing backslash\`,
+	`plain value`,
+	`ünïcödé ✓`,
+}
+
+func pickString(items []string) string {
+	return items[randomdata.Number(len(items))]
+}
+
+// sdCatalog generates the SD-ELEMENTs of random messages, the IANA
+// registered ones and private ones with values that need escaping.
+var sdCatalog = []func(seq int) SDElement{
+	func(seq int) SDElement {
+		synced := strconv.Itoa(randomdata.Number(2))
+		params := []SDParam{{"tzKnown", "1"}, {"isSynced", synced}}
+		if synced == "1" {
+			params = append(params, SDParam{"syncAccuracy", strconv.Itoa(randomdata.Number(1000, 1000000))})
+		}
+		return SDElement{ID: "timeQuality", Params: params}
+	},
+	func(seq int) SDElement {
+		return SDElement{ID: "origin", Params: []SDParam{
+			{"ip", randomdata.IpV4Address()}, {"enterpriseId", "32473"}, {"software", "log-generator"}, {"swVersion", "0.8.0"},
+		}}
+	},
+	func(seq int) SDElement {
+		return SDElement{ID: "meta", Params: []SDParam{
+			{"sequenceId", strconv.Itoa(seq)}, {"sysUpTime", strconv.Itoa(randomdata.Number(100, 100000000))}, {"language", pickString([]string{"en-US", "de-DE", "ja-JP"})},
+		}}
+	},
+	func(seq int) SDElement {
+		return SDElement{ID: "exampleSDID@32473", Params: []SDParam{
+			{"iut", strconv.Itoa(randomdata.Number(1, 10))}, {"eventSource", pickString([]string{"Application", "System", "Security"})}, {"eventID", strconv.Itoa(randomdata.Number(1000, 1100))},
+		}}
+	},
+	func(seq int) SDElement {
+		return SDElement{ID: "request@32473", Params: []SDParam{
+			{"path", pickString(sdValues)}, {"user", pickString(sdValues)}, {"status", strconv.Itoa(pickInt([]int{200, 404, 500}))},
+		}}
+	},
+	func(seq int) SDElement {
+		// repeated PARAM-NAMEs are allowed within an element
+		return SDElement{ID: "tags@32473", Params: []SDParam{
+			{"tag", pickString(sdValues)}, {"tag", pickString(sdValues)},
+		}}
+	},
+}
+
+func pickInt(items []int) int {
+	return items[randomdata.Number(len(items))]
+}
+
+// utf8Messages are sent with a BOM to exercise the UTF-8 handling of
+// receivers.
+var utf8Messages = []string{
+	"Überprüfung der Konfiguration abgeschlossen",
+	"connexion refusée pour l'utilisateur «admin»",
+	"バックアップが完了しました",
+	"служба перезапущена",
+	"déjà vu ✓ — 42 €",
+}
+
+// traceElement returns the structured data element of the trace context c.
+func traceElement(c tracing.Context) SDElement {
+	return SDElement{ID: "trace@32473", Params: []SDParam{
+		{"traceparent", c.Traceparent()}, {"trace_id", c.TraceID}, {"span_id", c.SpanID},
+	}}
+}
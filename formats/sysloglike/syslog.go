@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"math"
 	"math/rand"
 	"strconv"
 	"strings"
@@ -27,6 +28,8 @@ import (
 
 	"github.com/Pallinder/go-randomdata"
 	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats/langpack"
+	"github.com/kube-logging/log-generator/formats/tracing"
 	"github.com/kube-logging/log-generator/log"
 	"github.com/spf13/cast"
 )
@@ -35,15 +38,32 @@ import (
 var TemplateFS embed.FS
 
 type Syslog struct {
-	Facility    int
-	severity    int
-	dateTime    time.Time
-	Host        string
-	AppName     string
-	PID         int
-	Seq         int
-	Msg         string
+	Facility   int
+	severity   int
+	dateTime   time.Time
+	Host       string
+	AppName    string
+	PID        int
+	Seq        int
+	MsgID      string
+	SDElements []SDElement
+	Msg        string
+	// BOM marks RFC 5424 messages as UTF-8
+	BOM         bool
 	statefulSeq *sync.Map
+	nilFields   int
+
+	// Trace context of the application span, empty unless tracing is enabled
+	tracing.Context
+}
+
+func (t Syslog) EventTime() time.Time {
+	return t.dateTime
+}
+
+func (t Syslog) WithTime(tm time.Time) log.LogTemplateData {
+	t.dateTime = tm
+	return t
 }
 
 func (t Syslog) ISODateTime() string {
@@ -99,6 +119,74 @@ func (t Syslog) Severity() string {
 	return strconv.FormatInt(int64(t.severity), 10)
 }
 
+// Timestamp returns the RFC 5424 TIMESTAMP of the message.
+func (t Syslog) Timestamp() string {
+	if t.nilFields&nilTimestamp != 0 {
+		return nilValue
+	}
+	return t.ISODateTime()
+}
+
+// EventHost returns the host the message comes from.
+func (t Syslog) EventHost() string {
+	return t.Host
+}
+
+// HostName returns the RFC 5424 HOSTNAME of the message.
+func (t Syslog) HostName() string {
+	return t.orNil(t.Host, nilHost)
+}
+
+// App returns the RFC 5424 APP-NAME of the message.
+func (t Syslog) App() string {
+	return t.orNil(t.AppName, nilAppName)
+}
+
+// ProcID returns the RFC 5424 PROCID of the message.
+func (t Syslog) ProcID() string {
+	if t.PID == 0 {
+		return nilValue
+	}
+	return t.orNil(strconv.Itoa(t.PID), nilProcID)
+}
+
+// MessageID returns the RFC 5424 MSGID of the message.
+func (t Syslog) MessageID() string {
+	return t.orNil(t.MsgID, nilMsgID)
+}
+
+// StructuredData returns the RFC 5424 STRUCTURED-DATA of the message.
+func (t Syslog) StructuredData() string {
+	if len(t.SDElements) == 0 {
+		return nilValue
+	}
+	var b strings.Builder
+	for _, e := range t.SDElements {
+		b.WriteString(e.String())
+	}
+	return b.String()
+}
+
+// Message returns the RFC 5424 MSG of the message with its leading space, or
+// nothing if the message is empty.
+func (t Syslog) Message() string {
+	switch {
+	case t.Msg == "":
+		return ""
+	case t.BOM:
+		return " " + bom + t.Msg
+	default:
+		return " " + t.Msg
+	}
+}
+
+func (t Syslog) orNil(value string, field int) string {
+	if value == "" || t.nilFields&field != 0 {
+		return nilValue
+	}
+	return value
+}
+
 func (t Syslog) MonotonSeq(name string, start uint64) uint64 {
 	seq, ok := t.statefulSeq.Load(name)
 	if !ok {
@@ -177,30 +265,53 @@ func (r *RandomService) RandomApp() string {
 
 func (r *RandomService) SampleData(sequence *sync.Map) Syslog {
 	return Syslog{
-		Facility:    20,
-		severity:    5,
-		dateTime:    time.Now(),
-		Host:        conf.Viper.GetString("message.host"),
-		AppName:     conf.Viper.GetString("message.appname"),
-		PID:         1143,
-		Seq:         1,
+		Facility: 20,
+		severity: 5,
+		dateTime: log.Now(),
+		Host:     conf.Viper.GetString("message.host"),
+		AppName:  conf.Viper.GetString("message.appname"),
+		PID:      1143,
+		Seq:      1,
+		MsgID:    "ID47",
+		SDElements: []SDElement{{ID: "exampleSDID@32473", Params: []SDParam{
+			{"iut", "3"}, {"eventSource", "Application"}, {"eventID", "1011"},
+		}}},
 		Msg:         "An application event log entry...",
 		statefulSeq: sequence,
 	}
 }
 
-func (r *RandomService) RandomData(sequence *sync.Map) Syslog {
-	return Syslog{
-		Facility:    randomdata.Number(0, 24),
-		severity:    randomdata.Number(0, 8),
-		dateTime:    time.Now().UTC(),
+// RandomData returns a message with the facility, severity, MSGID and
+// structured data distributions of p.
+func (r *RandomService) RandomData(sequence *sync.Map, p *profile) Syslog {
+	t := Syslog{
+		Facility:    p.facilities.pick(),
+		severity:    p.severities.pick(),
+		dateTime:    log.Now().UTC(),
 		Host:        r.RandomHost(),
 		AppName:     r.RandomApp(),
 		PID:         randomdata.Number(1, 10000),
 		Seq:         randomdata.Number(1, 10000),
+		MsgID:       p.msgIDs[randomdata.Number(len(p.msgIDs))],
 		Msg:         fmt.Sprintf("An application event log entry %s %s", randomdata.Noun(), randomdata.Noun()),
 		statefulSeq: sequence,
+		nilFields:   p.nilFields(),
 	}
+	if t.MsgID == nilValue {
+		t.MsgID = ""
+	}
+	t.SDElements = p.structuredData(t.Seq)
+	if t.Context = tracing.Span(); t.IsValid() {
+		t.SDElements = append(t.SDElements, traceElement(t.Context))
+	}
+	// the random data comes from randomdata, so does the seed of the content of
+	// the language packs
+	t.Msg = langpack.Mix(rand.New(rand.NewSource(int64(randomdata.Number(math.MaxInt32)))), t.Msg)
+	if randomdata.Decimal(0, 1) < p.bomProbability {
+		t.BOM = true
+		t.Msg = pickString(utf8Messages)
+	}
+	return t
 }
 
 var syslogRandomService *RandomService
@@ -218,7 +329,37 @@ func NewSyslog(format string, sequence *sync.Map, templates []fs.FS) (*log.LogTe
 }
 
 func NewRandomSyslog(format string, sequence *sync.Map, templates []fs.FS) (*log.LogTemplate, error) {
-	return firstExistingTemplate(format, templates, syslogRandom().RandomData(sequence))
+	p, err := syslogProfile()
+	if err != nil {
+		return nil, err
+	}
+	return firstExistingTemplate(format, templates, syslogRandom().RandomData(sequence, p))
+}
+
+// NewSyslogMessage returns msg with the header of a syslog format, e.g.
+// "syslog.rfc5424", for log types that are delivered over syslog.
+func NewSyslogMessage(format string, facility, severity int, dateTime time.Time, host, appName string, pid int, msg string) (*log.LogTemplate, error) {
+	return NewTracedSyslogMessage(format, facility, severity, dateTime, host, appName, pid, msg, tracing.Context{})
+}
+
+// NewTracedSyslogMessage is NewSyslogMessage with the trace context c in the
+// structured data of RFC 5424 messages.
+func NewTracedSyslogMessage(format string, facility, severity int, dateTime time.Time, host, appName string, pid int, msg string, c tracing.Context) (*log.LogTemplate, error) {
+	t := Syslog{
+		Facility:    facility,
+		severity:    severity,
+		dateTime:    dateTime,
+		Host:        host,
+		AppName:     appName,
+		PID:         pid,
+		Msg:         msg,
+		statefulSeq: &sync.Map{},
+		Context:     c,
+	}
+	if c.IsValid() {
+		t.SDElements = []SDElement{traceElement(c)}
+	}
+	return log.NewLogTemplate(format, TemplateFS, t)
 }
 
 func firstExistingTemplate(format string, templates []fs.FS, data log.LogTemplateData) (tpl *log.LogTemplate, err error) {
@@ -0,0 +1,185 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/conf"
+)
+
+// Context is the W3C trace context of a span.
+type Context struct {
+	TraceID      string `json:"trace_id,omitempty"`
+	SpanID       string `json:"span_id,omitempty"`
+	ParentSpanID string `json:"parent_span_id,omitempty"`
+	Sampled      bool   `json:"-"`
+}
+
+// IsValid reports whether c belongs to a trace.
+func (c Context) IsValid() bool {
+	return c.TraceID != ""
+}
+
+// Traceparent returns the traceparent header of c, or nothing if c is not
+// valid.
+func (c Context) Traceparent() string {
+	if !c.IsValid() {
+		return ""
+	}
+	flags := 0
+	if c.Sampled {
+		flags = 1
+	}
+	return fmt.Sprintf("00-%s-%s-%02x", c.TraceID, c.SpanID, flags)
+}
+
+func randomID(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for {
+		r.Read(b)
+		// all zero IDs are invalid
+		for _, c := range b {
+			if c != 0 {
+				return hex.EncodeToString(b)
+			}
+		}
+	}
+}
+
+// NewContext returns the context of the root span of a new trace.
+func NewContext(r *rand.Rand, sampled bool) Context {
+	return Context{TraceID: randomID(r, 16), SpanID: randomID(r, 8), Sampled: sampled}
+}
+
+// Child returns the context of a new span with c as its parent.
+func (c Context) Child(r *rand.Rand) Context {
+	return Context{TraceID: c.TraceID, SpanID: randomID(r, 8), ParentSpanID: c.SpanID, Sampled: c.Sampled}
+}
+
+// Config configures the trace contexts of the generated requests.
+type Config struct {
+	// SampleRatio is the fraction of the traces with the sampled flag.
+	SampleRatio float64
+	// Seed makes the trace and span IDs reproducible, a random seed is used if 0.
+	Seed int64
+}
+
+func ConfigFromViper() Config {
+	return Config{
+		SampleRatio: conf.Viper.GetFloat64("tracing.sample_ratio"),
+		Seed:        conf.Viper.GetInt64("tracing.seed"),
+	}
+}
+
+// Tracer keeps the trace context of the current synthetic request, so the
+// events of every stream generated for it are correlated: the ingress span
+// of the access log and the application span of the events of the service
+// behind it.
+type Tracer struct {
+	sampleRatio float64
+
+	m       sync.Mutex
+	r       *rand.Rand
+	ingress Context
+	app     Context
+}
+
+func New(config Config) (*Tracer, error) {
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("sample ratio must be between 0 and 1, got %v", config.SampleRatio)
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Tracer{
+		sampleRatio: config.SampleRatio,
+		r:           rand.New(rand.NewSource(seed)),
+	}, nil
+}
+
+func (t *Tracer) start() {
+	t.ingress = NewContext(t.r, t.r.Float64() < t.sampleRatio)
+	t.app = t.ingress.Child(t.r)
+}
+
+// Start starts a new request and returns the context of its ingress span. A
+// nil Tracer returns an empty context.
+func (t *Tracer) Start() Context {
+	if t == nil {
+		return Context{}
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.start()
+	return t.ingress
+}
+
+// Span returns the context of the application span of the current request,
+// starting one if there is none. A nil Tracer returns an empty context.
+func (t *Tracer) Span() Context {
+	if t == nil {
+		return Context{}
+	}
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if !t.app.IsValid() {
+		t.start()
+	}
+	return t.app
+}
+
+var (
+	defaultTracer     *Tracer
+	defaultTracerOnce sync.Once
+)
+
+// Default returns the Tracer shared by the streams, or nil if the [tracing]
+// config section does not enable it.
+func Default() *Tracer {
+	defaultTracerOnce.Do(func() {
+		if !conf.Viper.GetBool("tracing.enabled") {
+			return
+		}
+		var err error
+		if defaultTracer, err = New(ConfigFromViper()); err != nil {
+			logger.Fatalf("invalid tracing config: %v", err)
+		}
+	})
+	return defaultTracer
+}
+
+// Start starts a new request of the default Tracer.
+func Start() Context {
+	return Default().Start()
+}
+
+// Span returns the application span of the current request of the default
+// Tracer.
+func Span() Context {
+	return Default().Span()
+}
@@ -0,0 +1,51 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestTracer(t *testing.T) {
+	traceparent := regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-0[01]$`)
+
+	tracer, err := New(Config{SampleRatio: 1, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ingress := tracer.Start()
+	if !traceparent.MatchString(ingress.Traceparent()) || !ingress.Sampled {
+		t.Errorf("Invalid traceparent of ingress span, %q", ingress.Traceparent())
+	}
+
+	app := tracer.Span()
+	if app.TraceID != ingress.TraceID || app.ParentSpanID != ingress.SpanID || app.SpanID == ingress.SpanID {
+		t.Errorf("Application span %+v is not a child of ingress span %+v", app, ingress)
+	}
+	if tracer.Span() != app {
+		t.Errorf("Expected the same application span within a request")
+	}
+
+	if next := tracer.Start(); next.TraceID == ingress.TraceID {
+		t.Errorf("Expected a new trace for a new request")
+	}
+
+	var disabled *Tracer
+	if c := disabled.Span(); c.IsValid() || c.Traceparent() != "" {
+		t.Errorf("Expected an empty context of a nil Tracer, got %+v", c)
+	}
+}
@@ -0,0 +1,405 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package web
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pallinder/go-randomdata"
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats/langpack"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// DefaultURLs is the URL catalog used when web.urls is not configured.
+const DefaultURLs = "GET /=20, GET /blog=8, GET /blog/post=10, GET /products=12, GET /products/item=15, " +
+	"GET /static/app.js=25, GET /static/style.css=25, GET /images/logo.png=20, GET /login=3, POST /login=3, " +
+	"GET /api/cart=6, POST /api/cart=4, PUT /api/cart=2, POST /api/checkout=1, GET /search=5"
+
+var externalReferers = []string{"-", "-", "-", "https://www.google.com/", "https://www.bing.com/", "https://duckduckgo.com/", "https://t.co/", "https://news.ycombinator.com/"}
+
+var crawlerAgents = []string{
+	"Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+	"Mozilla/5.0 (compatible; bingbot/2.0; +http://www.bing.com/bingbot.htm)",
+	"Mozilla/5.0 (compatible; AhrefsBot/7.0; +http://ahrefs.com/robot/)",
+}
+
+var probedPaths = []string{"/wp-login.php", "/.env", "/admin", "/phpmyadmin/", "/.git/config", "/robots.txt", "/sitemap.xml"}
+
+// TrafficConfig configures the web traffic model.
+type TrafficConfig struct {
+	// URLs lists "METHOD /path=weight" entries. The method defaults to GET.
+	URLs string
+	// Clients is the size of the client pool.
+	Clients int
+	// SessionLength is the mean number of requests of a client session.
+	SessionLength int
+	// ErrorBurstProbability is the chance of an error burst starting at a request.
+	ErrorBurstProbability float64
+	// ErrorBurstLength is the mean number of requests an error burst lasts.
+	ErrorBurstLength int
+	// LatencyMedian is the median response time of a successful request.
+	LatencyMedian time.Duration
+}
+
+func TrafficConfigFromViper() TrafficConfig {
+	return TrafficConfig{
+		URLs:                  conf.Viper.GetString("web.urls"),
+		Clients:               conf.Viper.GetInt("web.clients"),
+		SessionLength:         conf.Viper.GetInt("web.session_length"),
+		ErrorBurstProbability: conf.Viper.GetFloat64("web.error_burst_probability"),
+		ErrorBurstLength:      conf.Viper.GetInt("web.error_burst_length"),
+		LatencyMedian:         conf.Viper.GetDuration("web.latency_median"),
+	}
+}
+
+type url struct {
+	method string
+	path   string
+	weight int
+	// median response size in bytes
+	size int
+	// probe marks paths requested by scanners that do not exist
+	probe bool
+}
+
+type client struct {
+	service string
+	ip      string
+	agent   string
+	user    string
+	crawler bool
+
+	remaining int
+	page      string
+}
+
+// TrafficModel generates web requests that resemble the traffic of a real
+// site: clients browse in sessions with stable addresses and user agents,
+// follow referer chains, and errors arrive in correlated bursts.
+type TrafficModel struct {
+	m      sync.Mutex
+	rand   *rand.Rand
+	config TrafficConfig
+
+	urls        []url
+	totalWeight int
+	clients     []*client
+	// remaining requests of the current error burst
+	burst int
+}
+
+func NewTrafficModel(config TrafficConfig, seed int64) (*TrafficModel, error) {
+	if config.URLs == "" {
+		config.URLs = DefaultURLs
+	}
+	if config.Clients <= 0 {
+		config.Clients = 1
+	}
+	if config.SessionLength <= 0 {
+		config.SessionLength = 1
+	}
+	if config.ErrorBurstLength <= 0 {
+		config.ErrorBurstLength = 1
+	}
+	if config.LatencyMedian <= 0 {
+		config.LatencyMedian = 40 * time.Millisecond
+	}
+
+	urls, err := parseURLs(config.URLs)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &TrafficModel{
+		rand:   rand.New(rand.NewSource(seed)),
+		config: config,
+		urls:   urls,
+	}
+	for _, u := range urls {
+		t.totalWeight += u.weight
+	}
+	if t.totalWeight == 0 {
+		return nil, fmt.Errorf("URL catalog has no weight")
+	}
+	t.clients = t.newClients(config.Clients)
+
+	return t, nil
+}
+
+func parseURLs(s string) ([]url, error) {
+	var urls []url
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		target, weight := entry, 1
+		if i := strings.LastIndex(entry, "="); i >= 0 {
+			w, err := strconv.Atoi(strings.TrimSpace(entry[i+1:]))
+			if err != nil || w < 0 {
+				return nil, fmt.Errorf("invalid weight in URL %q", entry)
+			}
+			target, weight = strings.TrimSpace(entry[:i]), w
+		}
+
+		method, p := "GET", target
+		if fields := strings.Fields(target); len(fields) == 2 {
+			method, p = strings.ToUpper(fields[0]), fields[1]
+		}
+		if !strings.HasPrefix(p, "/") {
+			return nil, fmt.Errorf("invalid path in URL %q", entry)
+		}
+
+		urls = append(urls, url{method: method, path: p, weight: weight, size: medianSize(method, p)})
+	}
+
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("empty URL catalog")
+	}
+
+	return urls, nil
+}
+
+func medianSize(method, p string) int {
+	switch {
+	case strings.HasPrefix(p, "/api/"):
+		return 600
+	case method != "GET":
+		return 300
+	}
+
+	switch path.Ext(p) {
+	case ".js":
+		return 90000
+	case ".css":
+		return 25000
+	case ".png", ".jpg", ".jpeg", ".gif", ".webp":
+		return 40000
+	default:
+		return 12000
+	}
+}
+
+func (t *TrafficModel) newClients(n int) []*client {
+	// clients come from a handful of provider networks
+	networks := make([]string, 1+n/50)
+	for i := range networks {
+		networks[i] = fmt.Sprintf("%d.%d", 1+t.rand.Intn(223), t.rand.Intn(256))
+	}
+
+	clients := make([]*client, n)
+	for i := range clients {
+		c := &client{
+			service: services[t.rand.Intn(len(services))],
+			ip:      fmt.Sprintf("%s.%d.%d", networks[t.rand.Intn(len(networks))], t.rand.Intn(256), 1+t.rand.Intn(254)),
+			agent:   randomdata.UserAgentString(),
+			user:    "-",
+		}
+		if t.rand.Intn(20) == 0 {
+			c.crawler = true
+			c.agent = crawlerAgents[t.rand.Intn(len(crawlerAgents))]
+		}
+		clients[i] = c
+	}
+
+	return clients
+}
+
+// client picks a client, preferring the front of the pool so that a few
+// clients produce most of the traffic.
+func (t *TrafficModel) client() *client {
+	i := int(float64(len(t.clients)) * math.Pow(t.rand.Float64(), 2))
+	return t.clients[i]
+}
+
+func (t *TrafficModel) url() url {
+	n := t.rand.Intn(t.totalWeight)
+	for _, u := range t.urls {
+		n -= u.weight
+		if n < 0 {
+			return u
+		}
+	}
+	return t.urls[len(t.urls)-1]
+}
+
+// logNormal returns a heavy-tailed sample with the given median.
+func (t *TrafficModel) logNormal(median float64, sigma float64) float64 {
+	return median * math.Exp(sigma*t.rand.NormFloat64())
+}
+
+// Next returns the data of the next request.
+func (t *TrafficModel) Next() TemplateData {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	if t.burst > 0 {
+		t.burst--
+	} else if t.rand.Float64() < t.config.ErrorBurstProbability {
+		t.burst = 1 + t.rand.Intn(2*t.config.ErrorBurstLength)
+	}
+
+	c := t.client()
+	if c.remaining <= 0 {
+		c.remaining = 1 + int(t.rand.ExpFloat64()*float64(t.config.SessionLength))
+		c.page = externalReferers[t.rand.Intn(len(externalReferers))]
+		if c.user != "-" && t.rand.Intn(2) == 0 {
+			c.user = "-"
+		}
+	}
+	c.remaining--
+
+	u := t.url()
+	if c.crawler {
+		u.method = "GET"
+		if t.rand.Intn(10) == 0 {
+			u = url{method: "GET", path: probedPaths[t.rand.Intn(len(probedPaths))], size: 150, probe: true}
+		}
+	}
+
+	d := TemplateData{
+		Remote:            c.ip,
+		Host:              "-",
+		User:              c.user,
+		Time:              log.Now(),
+		Method:            u.method,
+		Path:              u.path,
+		Referer:           c.page,
+		Agent:             c.agent,
+		HttpXForwardedFor: "-",
+		Authority:         c.service + ".example.com",
+		RouteName:         c.service,
+	}
+
+	d.Code = t.status(u, c)
+	d.Size = t.size(u, d.Code)
+	d.UpstreamDuration = t.latency(u, d.Code)
+	d.Duration = d.UpstreamDuration + time.Duration(t.rand.Intn(5000))*time.Microsecond
+
+	// pages become the referer of the requests that follow them
+	if d.Code == 200 && u.method == "GET" && path.Ext(u.path) == "" && !strings.HasPrefix(u.path, "/api/") {
+		c.page = "https://" + d.Authority + u.path
+	}
+	if u.method == "POST" && u.path == "/login" && d.Code == 302 {
+		c.user = strings.ToLower(randomdata.SillyName())
+	}
+
+	// multilingual content, the path gets it as a segment without spaces as
+	// they would end the request line
+	d.Agent = langpack.Mix(t.rand, d.Agent)
+	if segment := langpack.Mix(t.rand, ""); segment != "" {
+		d.Path = strings.TrimSuffix(d.Path, "/") + "/" + strings.ReplaceAll(segment, " ", "-")
+	}
+
+	return d
+}
+
+func (t *TrafficModel) status(u url, c *client) int {
+	if t.burst > 0 && t.rand.Intn(10) < 7 {
+		return []int{500, 502, 503, 503, 504}[t.rand.Intn(5)]
+	}
+	if t.rand.Intn(2000) == 0 {
+		return 500
+	}
+
+	switch {
+	case u.probe:
+		return 404
+	case u.method == "POST" && u.path == "/login":
+		if t.rand.Intn(5) == 0 {
+			return 401
+		}
+		return 302
+	case strings.HasPrefix(u.path, "/api/") && c.user == "-" && u.method != "GET":
+		return []int{401, 403, 201}[t.rand.Intn(3)]
+	case path.Ext(u.path) != "" && c.page != "-" && t.rand.Intn(3) == 0:
+		return 304
+	case t.rand.Intn(50) == 0:
+		return 404
+	case u.method == "POST" || u.method == "PUT":
+		return 201
+	default:
+		return 200
+	}
+}
+
+func (t *TrafficModel) size(u url, code int) int {
+	switch {
+	case code == 304:
+		return 0
+	case code >= 300:
+		return 100 + t.rand.Intn(500)
+	}
+	return int(t.logNormal(float64(u.size), 0.8))
+}
+
+func (t *TrafficModel) latency(u url, code int) time.Duration {
+	median := float64(t.config.LatencyMedian)
+	if path.Ext(u.path) != "" {
+		median /= 4
+	}
+
+	switch code {
+	case 504:
+		return 30*time.Second + time.Duration(t.rand.Intn(100))*time.Millisecond
+	case 503:
+		return time.Duration(t.rand.Intn(5)) * time.Millisecond
+	}
+	if t.burst > 0 {
+		median *= 5
+	}
+
+	latency := t.logNormal(median, 0.7)
+	if t.rand.Intn(50) == 0 {
+		// pareto tail of slow requests
+		latency *= math.Pow(1-t.rand.Float64(), -1/1.5)
+	}
+
+	return time.Duration(latency)
+}
+
+var (
+	trafficOnce  sync.Once
+	trafficModel *TrafficModel
+	trafficErr   error
+)
+
+// LoadTrafficModel builds the traffic model configured by the [web] config
+// section, once, so an invalid config is reported at startup.
+func LoadTrafficModel() (*TrafficModel, error) {
+	trafficOnce.Do(func() {
+		trafficModel, trafficErr = NewTrafficModel(TrafficConfigFromViper(), time.Now().UnixNano())
+	})
+	return trafficModel, trafficErr
+}
+
+func defaultTrafficModel() *TrafficModel {
+	m, err := LoadTrafficModel()
+	if err != nil {
+		logger.Fatalf("invalid web traffic model: %v", err)
+	}
+	return m
+}
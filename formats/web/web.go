@@ -16,13 +16,19 @@ package web
 
 import (
 	"embed"
+	"encoding/json"
+	"fmt"
 	"math/rand"
 	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/Pallinder/go-randomdata"
 
-	wr "github.com/mroth/weightedrand"
+	"github.com/kube-logging/log-generator/formats/tracing"
+	"github.com/kube-logging/log-generator/incidents"
+	"github.com/kube-logging/log-generator/log"
 )
 
 //go:embed *.tmpl
@@ -40,6 +46,27 @@ type TemplateData struct {
 	Referer           string
 	Agent             string
 	HttpXForwardedFor string
+
+	// Fields of proxies and load balancers
+	RemotePort       int
+	Scheme           string
+	Protocol         string
+	Authority        string
+	RequestID        string
+	BytesReceived    int
+	Duration         time.Duration
+	UpstreamDuration time.Duration
+	UpstreamCluster  string
+	UpstreamHost     string
+	ResponseFlags    string
+	RouteName        string
+	TLSVersion       string
+	TLSCipher        string
+	// Requests received by the proxy since it started
+	RequestCount uint64
+
+	// Trace context of the ingress span, empty unless tracing is enabled
+	tracing.Context
 }
 
 func SampleData() TemplateData {
@@ -55,37 +82,220 @@ func SampleData() TemplateData {
 		Referer:           "-",
 		Agent:             "golang/generator PPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPPP",
 		HttpXForwardedFor: "-",
+		RemotePort:        54321,
+		Scheme:            "https",
+		Protocol:          "HTTP/1.1",
+		Authority:         "loggen.example.com",
+		RequestID:         "3e1c2b7a-5f4d-4c8e-9a0b-6d2f1e3c4b5a",
+		BytesReceived:     0,
+		Duration:          12 * time.Millisecond,
+		UpstreamDuration:  10 * time.Millisecond,
+		UpstreamCluster:   "outbound|8080||loggen.default.svc.cluster.local",
+		UpstreamHost:      "10.42.0.12:8080",
+		ResponseFlags:     "-",
+		RouteName:         "default",
+		TLSVersion:        "TLSv1.3",
+		TLSCipher:         "TLS_AES_128_GCM_SHA256",
+		RequestCount:      1,
 	}
 }
 
+func (t TemplateData) EventTime() time.Time {
+	return t.Time
+}
+
+func (t TemplateData) WithTime(tm time.Time) log.LogTemplateData {
+	t.Time = tm
+	return t
+}
+
 func (t TemplateData) WebServerDateTime() string {
 	return t.Time.Format("02/Jan/2006:15:04:05 -0700")
 }
 
+// RandomData returns the next request of the web traffic model configured by
+// the [web] config section.
 func RandomData() TemplateData {
 	rand.Seed(time.Now().UTC().UnixNano())
 
-	c, _ := wr.NewChooser(
-		wr.Choice{Item: 200, Weight: 7},
-		wr.Choice{Item: 404, Weight: 3},
-		wr.Choice{Item: 503, Weight: 1},
-		wr.Choice{Item: 302, Weight: 2},
-		wr.Choice{Item: 403, Weight: 2},
-	)
+	t := defaultTrafficModel().Next()
+	// incidents affect the service of the request
+	if rate := incidents.ErrorRate(t.RouteName); rate > 0 && rand.Float64() < rate {
+		t.Code = []int{500, 502, 503}[rand.Intn(3)]
+	}
+	if factor := incidents.LatencyFactor(t.RouteName); factor != 1 {
+		t.UpstreamDuration = time.Duration(float64(t.UpstreamDuration) * factor)
+		t.Duration = time.Duration(float64(t.Duration) * factor)
+	}
+	t.randomiseProxyFields()
+	t.Context = tracing.Start()
 
-	return TemplateData{
-		Remote:            randomdata.IpV4Address(),
-		Host:              "-",
-		User:              "-",
-		Time:              time.Now(),
-		Method:            randomdata.StringSample("GET", "POST", "PUT"),
-		Path:              randomdata.StringSample("/", "/blog", "/index.html", "/products"),
-		Code:              c.Pick().(int),
-		Size:              rand.Intn(25000-100) + 100,
-		Referer:           "-",
-		Agent:             randomdata.UserAgentString(),
-		HttpXForwardedFor: "-",
+	return t
+}
+
+// requestCount counts the random requests, as proxies count the requests they
+// receive.
+var requestCount atomic.Uint64
+
+var services = []string{"shop", "blog", "api", "auth", "checkout", "search"}
+
+// randomiseProxyFields fills the proxy fields of a request to the service
+// named by RouteName.
+func (t *TemplateData) randomiseProxyFields() {
+	service := t.RouteName
+
+	t.RemotePort = 1024 + rand.Intn(64511)
+	t.RequestCount = requestCount.Add(1)
+	t.Scheme = randomdata.StringSample("http", "https", "https", "https")
+	t.Protocol = randomdata.StringSample("HTTP/1.1", "HTTP/1.1", "HTTP/2")
+	t.RequestID = randomUUID()
+	if t.Method != "GET" {
+		t.BytesReceived = rand.Intn(4096)
+	}
+	t.UpstreamCluster = fmt.Sprintf("outbound|8080||%s.default.svc.cluster.local", service)
+	t.UpstreamHost = fmt.Sprintf("10.42.%d.%d:8080", rand.Intn(16), 1+rand.Intn(254))
+	t.ResponseFlags = "-"
+	t.TLSVersion, t.TLSCipher = "", ""
+	if t.Scheme == "https" {
+		t.TLSVersion = randomdata.StringSample("TLSv1.2", "TLSv1.3", "TLSv1.3")
+		t.TLSCipher = "TLS_AES_128_GCM_SHA256"
+		if t.TLSVersion == "TLSv1.2" {
+			t.TLSCipher = "ECDHE-RSA-AES128-GCM-SHA256"
+		}
+	}
+
+	switch t.Code {
+	case 503:
+		// no healthy upstream or upstream connection failure
+		t.ResponseFlags = randomdata.StringSample("UH", "UF", "URX")
+		t.UpstreamHost = "-"
+		t.UpstreamDuration = 0
+	case 504:
+		t.ResponseFlags = "UT"
+	}
+}
+
+func randomUUID() string {
+	return fmt.Sprintf("%08x-%04x-4%03x-%04x-%012x", rand.Uint32(), rand.Intn(1<<16), rand.Intn(1<<12), 0x8000|rand.Intn(1<<14), rand.Int63n(1<<48))
+}
+
+// Quote returns s as a JSON string.
+func (t TemplateData) Quote(s string) string {
+	out, _ := json.Marshal(s)
+	return string(out)
+}
+
+func (t TemplateData) StartTime() string {
+	return t.Time.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func (t TemplateData) DurationMillis() int64 {
+	return t.Duration.Milliseconds()
+}
+
+func (t TemplateData) DurationSeconds() float64 {
+	return t.Duration.Seconds()
+}
+
+// Overhead returns the time spent by the proxy itself.
+func (t TemplateData) Overhead() time.Duration {
+	return t.Duration - t.UpstreamDuration
+}
+
+func (t TemplateData) UpstreamDurationMillis() int64 {
+	return t.UpstreamDuration.Milliseconds()
+}
+
+func (t TemplateData) UnixTime() string {
+	return fmt.Sprintf("%d.%06d", t.Time.Unix(), t.Time.Nanosecond()/1000)
+}
+
+// EventHost returns the host serving the request: Host if it is set, the
+// upstream address otherwise.
+func (t TemplateData) EventHost() string {
+	if t.Host != "" && t.Host != "-" {
+		return t.Host
+	}
+	return t.UpstreamAddress()
+}
+
+// UpstreamAddress returns the upstream host without the port.
+func (t TemplateData) UpstreamAddress() string {
+	host, _, _ := strings.Cut(t.UpstreamHost, ":")
+	return host
+}
+
+// HAProxyDateTime returns the accept date of HAProxy logs.
+func (t TemplateData) HAProxyDateTime() string {
+	return t.Time.Format("02/Jan/2006:15:04:05.000")
+}
+
+// HAProxyTimers returns the TR/Tw/Tc/Tr/Ta timers of HAProxy HTTP logs.
+func (t TemplateData) HAProxyTimers() string {
+	if t.UpstreamHost == "-" {
+		return fmt.Sprintf("0/0/-1/-1/%d", t.DurationMillis())
+	}
+	tr := t.UpstreamDuration.Milliseconds()
+	return fmt.Sprintf("0/0/%d/%d/%d", (t.DurationMillis()-tr)/2, tr, t.DurationMillis())
+}
+
+// HAProxyTerminationState returns the session state at disconnection.
+func (t TemplateData) HAProxyTerminationState() string {
+	switch t.ResponseFlags {
+	case "UH", "UF", "URX":
+		return "SC--"
+	case "UT":
+		return "sH--"
+	default:
+		return "----"
+	}
+}
+
+func (t TemplateData) ALBTime() string {
+	return t.Time.UTC().Format("2006-01-02T15:04:05.000000Z")
+}
+
+func (t TemplateData) ALBRequestCreationTime() string {
+	return t.Time.Add(-t.Duration).UTC().Format("2006-01-02T15:04:05.000000Z")
+}
+
+func (t TemplateData) ALBType() string {
+	if t.Protocol == "HTTP/2" {
+		return "h2"
+	}
+	return t.Scheme
+}
+
+// Dash returns s, or "-" if s is empty.
+func (t TemplateData) Dash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// TraceHeader returns the X-Amzn-Trace-Id root added by AWS load balancers.
+func (t TemplateData) TraceHeader() string {
+	return fmt.Sprintf("Root=1-%08x-%s", t.Time.Unix(), strings.ReplaceAll(t.RequestID, "-", "")[:24])
+}
+
+// TLSVersionNumber returns the TLS version as the numeric protocol version.
+func (t TemplateData) TLSVersionNumber() int {
+	switch t.TLSVersion {
+	case "TLSv1.2":
+		return 0x0303
+	case "TLSv1.3":
+		return 0x0304
+	default:
+		return 0
+	}
+}
+
+func (t TemplateData) Port() int {
+	if t.Scheme == "https" {
+		return 443
 	}
+	return 80
 }
 
 func (t TemplateData) Severity() string {
@@ -8,6 +8,7 @@ require (
 	github.com/dhoomakethu/stress v0.0.0-20230620054616-291ff04e1c89
 	github.com/gin-gonic/gin v1.12.0
 	github.com/go-viper/encoding/ini v0.1.1
+	github.com/klauspost/compress v1.18.0
 	github.com/lthibault/jitterbug v2.0.0+incompatible
 	github.com/mroth/weightedrand v1.0.0
 	github.com/prometheus/client_golang v1.23.2
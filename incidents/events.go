@@ -0,0 +1,85 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incidents
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	logger "github.com/sirupsen/logrus"
+)
+
+var floodMessages = []string{
+	"connection reset by peer",
+	"upstream connect error or disconnect/reset before headers",
+	"failed to acquire lock: resource temporarily unavailable",
+	"retrying request after 503 Service Unavailable",
+}
+
+var patternWords = []string{
+	"quorum", "lease", "shard", "ledger", "tombstone", "watermark", "epoch", "manifest",
+	"checkpoint", "replica", "fence", "snapshot", "cursor", "segment", "vector", "beacon",
+}
+
+// newPattern returns a message that is unlike the messages of any format.
+func newPattern(r *rand.Rand) string {
+	word := func() string { return patternWords[r.Intn(len(patternWords))] }
+	return fmt.Sprintf("%s %s %08x rejected by %s %s: %s mismatch", word(), word(), r.Uint32(), word(), word(), word())
+}
+
+// event is an event of a flood or new_pattern incident.
+type event struct {
+	Application string    `json:"application,omitempty"`
+	Level       string    `json:"level"`
+	MSG         string    `json:"msg"`
+	Incident    string    `json:"incident"`
+	Time        time.Time `json:"time"`
+
+	isFramed bool
+}
+
+func (e *event) String() (string, float64) {
+	out, err := json.Marshal(e)
+	if err != nil {
+		logger.Error(err)
+	}
+	return string(out), float64(len(out))
+}
+
+func (e *event) Timestamp() time.Time {
+	return e.Time
+}
+
+func (e *event) SetTime(t time.Time) {
+	e.Time = t
+}
+
+func (e *event) IsFramed() bool {
+	return e.isFramed
+}
+
+func (e *event) SetFramed(f bool) {
+	e.isFramed = f
+}
+
+func (e *event) Labels() prometheus.Labels {
+	return prometheus.Labels{
+		"type":     "incidents",
+		"severity": e.Level,
+	}
+}
@@ -0,0 +1,365 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incidents
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/log"
+	"github.com/kube-logging/log-generator/metrics"
+)
+
+// kinds are the kinds of incidents and their defaults.
+var kinds = map[string]Incident{
+	// a Rate fraction of the events of the target become errors
+	"error_spike": {Rate: 0.5},
+	// the target emits no events
+	"silence": {},
+	// the response times of the target grow by Factor
+	"latency": {Factor: 5},
+	// the target repeats Message Rate times per tick
+	"flood": {Rate: 50},
+	// the target logs a pattern never seen before Rate times per tick
+	"new_pattern": {Rate: 1},
+}
+
+// Kinds returns the names of the kinds of incidents.
+func Kinds() []string {
+	names := make([]string, 0, len(kinds))
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Incident is a time-bounded anomaly of the generated events.
+type Incident struct {
+	ID   string `json:"id"`
+	Kind string `json:"kind"`
+	// Target is the app, service or stream the incident affects, all of them
+	// if empty.
+	Target string `json:"target,omitempty"`
+	// Rate is the fraction of error events of error_spike incidents and the
+	// number of events per tick of flood and new_pattern incidents.
+	Rate float64 `json:"rate,omitempty"`
+	// Factor multiplies the response times of latency incidents.
+	Factor float64 `json:"factor,omitempty"`
+	// Message is the message of flood and new_pattern incidents, a random one
+	// is used if empty.
+	Message string `json:"message,omitempty"`
+	// Delay is the number of seconds before the incident starts.
+	Delay int `json:"delay,omitempty"`
+	// Duration is the number of seconds the incident lasts.
+	Duration int       `json:"duration"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Active   bool      `json:"active"`
+}
+
+func (i *Incident) matches(target string) bool {
+	return i.Target == "" || strings.EqualFold(i.Target, target)
+}
+
+func (i *Incident) activeAt(now time.Time) bool {
+	return !now.Before(i.Start) && now.Before(i.End)
+}
+
+func (i *Incident) labels() map[string]string {
+	return map[string]string{"id": i.ID, "kind": i.Kind, "target": i.Target}
+}
+
+// Manager keeps the incidents and affects the generated events while they
+// are active.
+type Manager struct {
+	m         sync.Mutex
+	r         *rand.Rand
+	seq       int
+	incidents []*Incident
+	record    io.WriteCloser
+}
+
+// New returns a Manager recording the incidents in recordFile, one JSON line
+// per incident, if it is not empty.
+func New(recordFile string) (*Manager, error) {
+	m := &Manager{r: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	if recordFile != "" {
+		f, err := os.OpenFile(recordFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("opening incident record file: %w", err)
+		}
+		m.record = f
+	}
+	return m, nil
+}
+
+// Add schedules i and returns it with its ID, start and end.
+func (m *Manager) Add(i Incident) (Incident, error) {
+	defaults, ok := kinds[i.Kind]
+	if !ok {
+		return i, fmt.Errorf("unknown kind %q, valid kinds: %s", i.Kind, strings.Join(Kinds(), ", "))
+	}
+	if i.Duration <= 0 {
+		return i, fmt.Errorf("duration must be positive, got %d", i.Duration)
+	}
+	if i.Delay < 0 {
+		return i, fmt.Errorf("delay must not be negative, got %d", i.Delay)
+	}
+	if i.Rate == 0 {
+		i.Rate = defaults.Rate
+	}
+	if i.Factor == 0 {
+		i.Factor = defaults.Factor
+	}
+	if i.Rate < 0 || i.Kind == "error_spike" && i.Rate > 1 {
+		return i, fmt.Errorf("invalid rate %v", i.Rate)
+	}
+	if i.Factor < 0 {
+		return i, fmt.Errorf("factor must not be negative, got %v", i.Factor)
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if i.Message == "" {
+		switch i.Kind {
+		case "flood":
+			i.Message = floodMessages[m.r.Intn(len(floodMessages))]
+		case "new_pattern":
+			i.Message = newPattern(m.r)
+		}
+	}
+
+	m.seq++
+	i.ID = fmt.Sprintf("%s-%d", i.Kind, m.seq)
+	i.Start = log.Now().Add(time.Duration(i.Delay) * time.Second)
+	i.End = i.Start.Add(time.Duration(i.Duration) * time.Second)
+	i.Active = false
+	m.incidents = append(m.incidents, &i)
+
+	if m.record != nil {
+		line, err := json.Marshal(i)
+		if err == nil {
+			_, err = m.record.Write(append(line, '\n'))
+		}
+		if err != nil {
+			logger.Errorf("error recording incident: %v", err)
+		}
+	}
+	logger.Infof("incident %s scheduled from %s to %s", i.ID, i.Start.Format(time.RFC3339), i.End.Format(time.RFC3339))
+
+	return i, nil
+}
+
+// Incidents returns the incidents, active or not.
+func (m *Manager) Incidents() []Incident {
+	if m == nil {
+		return nil
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	now := log.Now()
+	incidents := make([]Incident, 0, len(m.incidents))
+	for _, i := range m.incidents {
+		c := *i
+		c.Active = i.activeAt(now)
+		incidents = append(incidents, c)
+	}
+	return incidents
+}
+
+// active returns the active incidents of kind affecting target.
+func (m *Manager) active(kind, target string) []*Incident {
+	now := log.Now()
+	var active []*Incident
+	for _, i := range m.incidents {
+		if i.Kind == kind && i.matches(target) && i.activeAt(now) {
+			active = append(active, i)
+		}
+	}
+	return active
+}
+
+// ErrorRate returns the fraction of the events of target that are errors, 0
+// if no error_spike incident affects it.
+func (m *Manager) ErrorRate(target string) float64 {
+	if m == nil {
+		return 0
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	rate := 0.0
+	for _, i := range m.active("error_spike", target) {
+		rate = max(rate, i.Rate)
+	}
+	return rate
+}
+
+// LatencyFactor returns the factor the response times of target grow by, 1 if
+// no latency incident affects it.
+func (m *Manager) LatencyFactor(target string) float64 {
+	if m == nil {
+		return 1
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	factor := 1.0
+	for _, i := range m.active("latency", target) {
+		factor = max(factor, i.Factor)
+	}
+	return factor
+}
+
+// Silenced reports whether a silence incident affects source, or host if it
+// is not empty.
+func (m *Manager) Silenced(source string, host string) bool {
+	if m == nil {
+		return false
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	if len(m.active("silence", source)) > 0 {
+		return true
+	}
+	return host != "" && len(m.active("silence", host)) > 0
+}
+
+// Events returns the events of the active flood and new_pattern incidents for
+// a tick, and updates the loggen_incident_active metric of the incidents
+// that started or ended since the last tick.
+func (m *Manager) Events() []log.Log {
+	if m == nil {
+		return nil
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+
+	now := log.Now()
+	var events []log.Log
+	for _, i := range m.incidents {
+		active := i.activeAt(now)
+		if active != i.Active {
+			i.Active = active
+			if active {
+				metrics.IncidentActive.With(i.labels()).Set(1)
+				logger.Infof("incident %s started", i.ID)
+			} else {
+				metrics.IncidentActive.With(i.labels()).Set(0)
+				logger.Infof("incident %s ended", i.ID)
+			}
+		}
+		if !active || (i.Kind != "flood" && i.Kind != "new_pattern") {
+			continue
+		}
+
+		n := int(i.Rate)
+		if m.r.Float64() < i.Rate-float64(n) {
+			n++
+		}
+		for ; n > 0; n-- {
+			e := &event{Application: i.Target, Level: "error", MSG: i.Message, Incident: i.ID, Time: now}
+			if i.Kind == "new_pattern" {
+				e.Level = "warning"
+				e.MSG = fmt.Sprintf("%s id=%d took=%dms", i.Message, m.r.Intn(1000000), m.r.Intn(5000))
+			}
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// Close closes the incident record file.
+func (m *Manager) Close() {
+	if m == nil || m.record == nil {
+		return
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+	m.record.Close()
+	m.record = nil
+}
+
+var (
+	defaultManager     *Manager
+	defaultManagerOnce sync.Once
+)
+
+// Default returns the Manager of the incidents of the API and the ones
+// scheduled by the [incidents.schedule.<name>] config sections.
+func Default() *Manager {
+	defaultManagerOnce.Do(func() {
+		var err error
+		if defaultManager, err = New(conf.Viper.GetString("incidents.record_file")); err != nil {
+			logger.Fatalf("invalid incidents config: %v", err)
+		}
+
+		names := []string{}
+		for name := range conf.Viper.GetStringMap("incidents.schedule") {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			key := "incidents.schedule." + name
+			if _, err := defaultManager.Add(Incident{
+				Kind:     conf.Viper.GetString(key + ".kind"),
+				Target:   conf.Viper.GetString(key + ".target"),
+				Rate:     conf.Viper.GetFloat64(key + ".rate"),
+				Factor:   conf.Viper.GetFloat64(key + ".factor"),
+				Message:  conf.Viper.GetString(key + ".message"),
+				Delay:    int(conf.Viper.GetDuration(key + ".after").Seconds()),
+				Duration: int(conf.Viper.GetDuration(key + ".duration").Seconds()),
+			}); err != nil {
+				logger.Fatalf("invalid incident %q: %v", name, err)
+			}
+		}
+	})
+	return defaultManager
+}
+
+// ErrorRate returns the error rate of target of the default Manager.
+func ErrorRate(target string) float64 {
+	return Default().ErrorRate(target)
+}
+
+// LatencyFactor returns the latency factor of target of the default Manager.
+func LatencyFactor(target string) float64 {
+	return Default().LatencyFactor(target)
+}
+
+// Silenced reports whether source or host is silenced by the default Manager.
+func Silenced(source string, host string) bool {
+	return Default().Silenced(source, host)
+}
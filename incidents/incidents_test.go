@@ -0,0 +1,148 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incidents
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-logging/log-generator/log"
+)
+
+func TestIncidents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "incidents.jsonl")
+	m, err := New(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, i := range []Incident{
+		{Kind: "unknown", Duration: 60},
+		{Kind: "silence"},
+		{Kind: "error_spike", Rate: 2, Duration: 60},
+	} {
+		if _, err := m.Add(i); err == nil {
+			t.Errorf("Expected an error adding %+v", i)
+		}
+	}
+
+	for _, i := range []Incident{
+		{Kind: "error_spike", Target: "checkout", Rate: 0.8, Duration: 60},
+		{Kind: "error_spike", Target: "checkout", Rate: 1, Delay: 60, Duration: 60},
+		{Kind: "latency", Factor: 3, Duration: 60},
+		{Kind: "silence", Target: "Nginx", Duration: 60},
+		{Kind: "flood", Target: "checkout", Rate: 10, Message: "connection reset by peer", Duration: 60},
+		{Kind: "new_pattern", Target: "payment", Rate: 2, Duration: 60},
+		{Kind: "silence", Target: "db-01", Duration: 60},
+	} {
+		if _, err := m.Add(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if rate := m.ErrorRate("checkout"); rate != 0.8 {
+		t.Errorf("Expected error rate 0.8 of the active incident, got %v", rate)
+	}
+	if rate := m.ErrorRate("payment"); rate != 0 {
+		t.Errorf("Expected no errors of other targets, got %v", rate)
+	}
+	if factor := m.LatencyFactor("payment"); factor != 3 {
+		t.Errorf("Expected latency factor 3 of all targets, got %v", factor)
+	}
+	if !m.Silenced("nginx", "") || m.Silenced("apache", "") {
+		t.Errorf("Expected only nginx to be silenced")
+	}
+	if !m.Silenced("sysloglike", "db-01") || m.Silenced("sysloglike", "db-02") {
+		t.Errorf("Expected only the events of host db-01 to be silenced")
+	}
+
+	counts := map[string]int{}
+	for _, e := range m.Events() {
+		msg, _ := e.String()
+		var fields map[string]string
+		if err := json.Unmarshal([]byte(msg), &fields); err != nil {
+			t.Fatal(err)
+		}
+		counts[fields["incident"]]++
+		if fields["incident"] == "flood-5" && fields["msg"] != "connection reset by peer" {
+			t.Errorf("Unexpected flood message %q", fields["msg"])
+		}
+	}
+	if counts["flood-5"] != 10 || counts["new_pattern-6"] != 2 {
+		t.Errorf("Unexpected number of incident events, %v", counts)
+	}
+
+	active := 0
+	for _, i := range m.Incidents() {
+		if i.Active {
+			active++
+		}
+	}
+	if active != 6 {
+		t.Errorf("Expected 6 active incidents, got %d", active)
+	}
+
+	m.Close()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lines := 0
+	for s := bufio.NewScanner(f); s.Scan(); lines++ {
+		var i Incident
+		if err := json.Unmarshal(s.Bytes(), &i); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(i.ID, i.Kind) || !i.End.After(i.Start) {
+			t.Errorf("Unexpected incident record, %s", s.Text())
+		}
+	}
+	if lines != 7 {
+		t.Errorf("Expected 7 incident records, got %d", lines)
+	}
+}
+
+func TestSimulatedTime(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	log.SetNow(start)
+	defer log.SetNow(time.Time{})
+
+	m, err := New("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	i, err := m.Add(Incident{Kind: "flood", Rate: 1, Delay: 60, Duration: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !i.Start.Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected the incident to start a minute after the simulated time, got %v", i.Start)
+	}
+	if len(m.Events()) != 0 {
+		t.Errorf("Expected no events before the incident starts")
+	}
+
+	log.SetNow(start.Add(90 * time.Second))
+	events := m.Events()
+	if len(events) != 1 || !events[0].(log.Timestamper).Timestamp().Equal(start.Add(90*time.Second)) {
+		t.Errorf("Expected an event at the simulated time, got %v", events)
+	}
+}
@@ -15,6 +15,9 @@
 package log
 
 import (
+	"sync/atomic"
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -24,3 +27,84 @@ type Log interface {
 	IsFramed() bool
 	SetFramed(bool)
 }
+
+// Timestamper is a Log whose timestamp can be set before it is rendered.
+// Events are stamped with the time they are rendered at otherwise.
+type Timestamper interface {
+	// Timestamp returns the time of the event, zero if it has none yet.
+	Timestamp() time.Time
+	SetTime(t time.Time)
+}
+
+// Hosted is a Log emitted by a named host.
+type Hosted interface {
+	Host() string
+}
+
+// simulated is the simulated time of a backfill, nil in real time.
+var simulated atomic.Pointer[time.Time]
+
+// Now returns the simulated time set by SetNow, or the current time.
+func Now() time.Time {
+	if t := simulated.Load(); t != nil {
+		return *t
+	}
+	return time.Now()
+}
+
+// SetNow sets the time returned by Now, e.g. the tick of a backfill. A zero t
+// restores the current time.
+func SetNow(t time.Time) {
+	if t.IsZero() {
+		simulated.Store(nil)
+		return
+	}
+	simulated.Store(&t)
+}
+
+// TimeOrNow returns t, or the current time if t is zero.
+func TimeOrNow(t time.Time) time.Time {
+	if t.IsZero() {
+		return Now()
+	}
+	return t
+}
+
+// Rendered is a Log with its content already rendered, so sending it to
+// several writers produces the same event on each of them.
+type Rendered struct {
+	msg      string
+	size     float64
+	labels   prometheus.Labels
+	isFramed bool
+}
+
+func Render(l Log) *Rendered {
+	if r, ok := l.(*Rendered); ok {
+		return r
+	}
+
+	msg, size := l.String()
+	return &Rendered{
+		msg:      msg,
+		size:     size,
+		labels:   l.Labels(),
+		isFramed: l.IsFramed(),
+	}
+}
+
+func (r *Rendered) String() (string, float64) {
+	return r.msg, r.size
+}
+
+func (r *Rendered) Labels() prometheus.Labels {
+	return r.labels
+}
+
+func (r *Rendered) IsFramed() bool {
+	return r.isFramed
+}
+
+func (r *Rendered) SetFramed(f bool) {
+	r.isFramed = f
+}
@@ -0,0 +1,82 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package logtest provides a log.Log and a writer for tests.
+package logtest
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/kube-logging/log-generator/log"
+)
+
+// Log is an event of the test type. It renders Msg, or "event <n>" on its
+// n-th rendering if Msg is empty, like randomised events that render
+// differently every time.
+type Log struct {
+	Msg string
+	// Severity is the severity label, info if empty
+	Severity string
+	// Renders counts the renderings of the event
+	Renders int
+
+	isFramed bool
+}
+
+// New returns an info event rendering msg.
+func New(msg string) *Log {
+	return &Log{Msg: msg}
+}
+
+func (l *Log) String() (string, float64) {
+	l.Renders++
+	msg := l.Msg
+	if msg == "" {
+		msg = "event " + strconv.Itoa(l.Renders)
+	}
+	return msg, float64(len(msg))
+}
+
+func (l *Log) Labels() prometheus.Labels {
+	severity := l.Severity
+	if severity == "" {
+		severity = "info"
+	}
+	return prometheus.Labels{"type": "test", "severity": severity}
+}
+
+func (l *Log) IsFramed() bool {
+	return l.isFramed
+}
+
+func (l *Log) SetFramed(f bool) {
+	l.isFramed = f
+}
+
+// Writer records the events sent to it.
+type Writer struct {
+	Msgs   []string
+	Closed bool
+}
+
+func (w *Writer) Send(l log.Log) {
+	msg, _ := l.String()
+	w.Msgs = append(w.Msgs, msg)
+}
+
+func (w *Writer) Close() {
+	w.Closed = true
+}
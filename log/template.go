@@ -19,6 +19,7 @@ import (
 	"io/fs"
 	"strings"
 	"text/template"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
@@ -29,6 +30,19 @@ type LogTemplateData interface {
 	Severity() string
 }
 
+// HostedTemplateData is LogTemplateData of an event emitted by a host.
+type HostedTemplateData interface {
+	LogTemplateData
+	EventHost() string
+}
+
+// TimedTemplateData is LogTemplateData with a timestamp.
+type TimedTemplateData interface {
+	LogTemplateData
+	EventTime() time.Time
+	WithTime(t time.Time) LogTemplateData
+}
+
 type LogTemplate struct {
 	Format string
 
@@ -81,6 +95,29 @@ func (l *LogTemplate) SetFramed(f bool) {
 	l.isFramed = f
 }
 
+// Timestamp returns the timestamp of data that has one.
+func (l *LogTemplate) Timestamp() time.Time {
+	if d, ok := l.data.(TimedTemplateData); ok {
+		return d.EventTime()
+	}
+	return time.Time{}
+}
+
+// SetTime sets the timestamp of data that has one.
+func (l *LogTemplate) SetTime(t time.Time) {
+	if d, ok := l.data.(TimedTemplateData); ok {
+		l.data = d.WithTime(t)
+	}
+}
+
+// Host returns the host of data that has one.
+func (l *LogTemplate) Host() string {
+	if d, ok := l.data.(HostedTemplateData); ok {
+		return d.EventHost()
+	}
+	return ""
+}
+
 func (l *LogTemplate) Labels() prometheus.Labels {
 	return prometheus.Labels{
 		"type":     l.Format,
@@ -0,0 +1,209 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggen
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats"
+	"github.com/kube-logging/log-generator/writers"
+)
+
+// defaultDestination is the name of the destination configured by the legacy
+// [destination] section, used when no [destinations.<name>] sections exist.
+const defaultDestination = "default"
+
+func destinationsFromConfig() map[string]writers.DestinationConfig {
+	destinations := map[string]writers.DestinationConfig{}
+
+	for name := range conf.Viper.GetStringMap("destinations") {
+		key := "destinations." + name
+		identifier := conf.Viper.GetString(key + ".identifier")
+		if identifier == "" {
+			identifier = conf.Viper.GetString("message.appname")
+		}
+		compressionLevel := writers.DefaultLumberjackCompressionLevel
+		if conf.Viper.IsSet(key + ".compression_level") {
+			compressionLevel = conf.Viper.GetInt(key + ".compression_level")
+		}
+		destinations[name] = writers.DestinationConfig{
+			Type:    conf.Viper.GetString(key + ".type"),
+			Network: conf.Viper.GetString(key + ".network"),
+			Address: conf.Viper.GetString(key + ".address"),
+			File:    fileConfig(key),
+			Output:  outputConfig(key),
+
+			Identifier: identifier,
+			Fields:     conf.Viper.GetStringMapString(key + ".fields"),
+			Window:     conf.Viper.GetInt(key + ".window"),
+
+			CompressionLevel: compressionLevel,
+			TLS: writers.TLSConfig{
+				Enabled:            conf.Viper.GetBool(key + ".tls.enabled"),
+				CAFile:             conf.Viper.GetString(key + ".tls.ca_file"),
+				CertFile:           conf.Viper.GetString(key + ".tls.cert_file"),
+				KeyFile:            conf.Viper.GetString(key + ".tls.key_file"),
+				ServerName:         conf.Viper.GetString(key + ".tls.server_name"),
+				InsecureSkipVerify: conf.Viper.GetBool(key + ".tls.insecure_skip_verify"),
+			},
+		}
+	}
+
+	if len(destinations) > 0 {
+		return destinations
+	}
+
+	legacy := writers.DestinationConfig{Type: "stdout"}
+	if len(conf.Viper.GetString("destination.network")) != 0 {
+		legacy = writers.DestinationConfig{
+			Type:    "network",
+			Network: conf.Viper.GetString("destination.network"),
+			Address: conf.Viper.GetString("destination.address"),
+		}
+	} else if len(conf.Viper.GetString("destination.file.path")) != 0 {
+		legacy = writers.DestinationConfig{
+			Type:   "file",
+			File:   fileConfig("destination.file"),
+			Output: outputConfig("destination.file"),
+		}
+	}
+	destinations[defaultDestination] = legacy
+
+	return destinations
+}
+
+// fileConfig reads the file writer settings under key, falling back to the
+// [destination.file] defaults for the unset ones.
+func fileConfig(key string) writers.FileLogWriterConfig {
+	get := func(name string) string {
+		if conf.Viper.IsSet(key + "." + name) {
+			return key + "." + name
+		}
+		return "destination.file." + name
+	}
+
+	return writers.FileLogWriterConfig{
+		Path:           conf.Viper.GetString(get("path")),
+		Create:         conf.Viper.GetBool(get("create")),
+		Append:         conf.Viper.GetBool(get("append")),
+		FileMode:       os.FileMode(conf.Viper.GetUint32(get("mode"))),
+		DirMode:        os.FileMode(conf.Viper.GetUint32(get("dir_mode"))),
+		SyncAfterWrite: conf.Viper.GetBool(get("sync")),
+	}
+}
+
+func outputConfig(key string) writers.OutputConfig {
+	return writers.OutputConfig{
+		Compression:   conf.Viper.GetString(key + ".compression"),
+		BatchSize:     conf.Viper.GetInt(key + ".batch_size"),
+		FlushInterval: conf.Viper.GetDuration(key + ".flush_interval"),
+	}
+}
+
+// destinationList parses a comma separated list of destination names.
+func destinationList(s string) []string {
+	names := []string{}
+	for _, name := range strings.Split(s, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// streamDestinationKeys returns the config keys of the destination lists of
+// the streams and replay sources.
+func streamDestinationKeys() []string {
+	keys := []string{}
+	for _, source := range []string{"nginx", "apache", "golang", "tracing", "incidents"} {
+		keys = append(keys, source+".destinations")
+	}
+	for t := range formats.FormatsByType() {
+		keys = append(keys, t+".destinations")
+	}
+	for name := range conf.Viper.GetStringMap("replay.sources") {
+		keys = append(keys, "replay.sources."+name+".destinations")
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (l *LogGen) destinationNames() []string {
+	names := make([]string, 0, len(l.destinations))
+	for name := range l.destinations {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// StdoutBuffered tells whether a stdout destination compresses or batches its
+// events.
+func (l *LogGen) StdoutBuffered() bool {
+	for _, config := range l.destinations {
+		if config.Type == "stdout" && config.Output.Buffered() {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *LogGen) validateDestinations(names []string) error {
+	for _, name := range names {
+		if _, exists := l.destinations[strings.ToLower(name)]; !exists {
+			return fmt.Errorf("destination %q does not exist", name)
+		}
+	}
+	return nil
+}
+
+func (l *LogGen) openDestinations() {
+	l.writers = make(map[string]writers.LogWriter, len(l.destinations))
+	for name, config := range l.destinations {
+		w, err := writers.NewDestination(config)
+		if err != nil {
+			logger.Fatalf("failed to open destination %q: %v", name, err)
+		}
+		l.writers[name] = w
+	}
+}
+
+func (l *LogGen) closeDestinations() {
+	for _, w := range l.writers {
+		w.Close()
+	}
+}
+
+// writerFor returns a writer sending to all the named destinations, or to the
+// default destinations if names is empty.
+func (l *LogGen) writerFor(names []string) writers.LogWriter {
+	if len(names) == 0 {
+		names = l.DefaultDestinations
+	}
+
+	ws := make([]writers.LogWriter, 0, len(names))
+	for _, name := range names {
+		if w, ok := l.writers[strings.ToLower(name)]; ok {
+			ws = append(ws, w)
+		}
+	}
+	return writers.NewMultiWriter(ws...)
+}
@@ -0,0 +1,93 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggen
+
+import (
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/log/logtest"
+	"github.com/kube-logging/log-generator/writers"
+)
+
+func TestDestinationsFromConfig(t *testing.T) {
+	conf.Init()
+	conf.Viper.Set("destinations.local.type", "file")
+	conf.Viper.Set("destinations.local.path", "/tmp/loggen.log")
+	conf.Viper.Set("destinations.collector.type", "network")
+	conf.Viper.Set("destinations.collector.network", "tcp")
+	conf.Viper.Set("destinations.collector.address", "localhost:5170")
+
+	destinations := destinationsFromConfig()
+	if len(destinations) != 2 {
+		t.Fatalf("Expected 2 destinations, got %v", destinations)
+	}
+	if d := destinations["local"]; d.Type != "file" || d.File.Path != "/tmp/loggen.log" {
+		t.Errorf("Unexpected local destination %+v", d)
+	}
+	if d := destinations["collector"]; d.Type != "network" || d.Network != "tcp" || d.Address != "localhost:5170" {
+		t.Errorf("Unexpected collector destination %+v", d)
+	}
+
+	conf.Viper.Set("replay.sources.prod.path", "/tmp/prod.log")
+	keys := streamDestinationKeys()
+	for _, key := range []string{"nginx.destinations", "structured.destinations", "replay.sources.prod.destinations"} {
+		if !slices.Contains(keys, key) {
+			t.Errorf("Expected %q in the destination lists %v", key, keys)
+		}
+	}
+
+	conf.Init()
+	destinations = destinationsFromConfig()
+	if d, ok := destinations[defaultDestination]; !ok || len(destinations) != 1 || d.Type != "stdout" {
+		t.Errorf("Expected the legacy stdout destination, got %v", destinations)
+	}
+}
+
+func TestWriterFor(t *testing.T) {
+	local, collector, other := &logtest.Writer{}, &logtest.Writer{}, &logtest.Writer{}
+	l := &LogGen{
+		DefaultDestinations: []string{"local"},
+		destinations: map[string]writers.DestinationConfig{
+			"local": {}, "collector": {}, "other": {},
+		},
+		writers: map[string]writers.LogWriter{
+			"local": local, "collector": collector, "other": other,
+		},
+	}
+
+	if names := l.destinationNames(); !sort.StringsAreSorted(names) || len(names) != 3 {
+		t.Errorf("Expected the sorted destination names, got %v", names)
+	}
+	if err := l.validateDestinations([]string{"Local", "missing"}); err == nil {
+		t.Error("Expected an error for a missing destination")
+	}
+
+	event := &logtest.Log{Msg: "event"}
+	l.writerFor([]string{"Local", "collector"}).Send(event)
+	l.writerFor(nil).Send(event)
+
+	if event.Renders != 2 {
+		t.Errorf("Expected an event to be rendered once for all of its destinations, got %d renders", event.Renders)
+	}
+	if len(local.Msgs) != 2 || len(collector.Msgs) != 1 || len(other.Msgs) != 0 {
+		t.Errorf("Unexpected fan-out: local %q, collector %q, other %q", local.Msgs, collector.Msgs, other.Msgs)
+	}
+	if local.Msgs[0] != collector.Msgs[0] {
+		t.Errorf("Expected the same event on every destination, got %q and %q", local.Msgs[0], collector.Msgs[0])
+	}
+}
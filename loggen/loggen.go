@@ -18,22 +18,36 @@ import (
 	"bytes"
 	"container/list"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/lthibault/jitterbug"
 	logger "github.com/sirupsen/logrus"
 
+	"github.com/kube-logging/log-generator/backfill"
+	"github.com/kube-logging/log-generator/chaos"
 	"github.com/kube-logging/log-generator/conf"
 	"github.com/kube-logging/log-generator/formats"
 	"github.com/kube-logging/log-generator/formats/golang"
+	"github.com/kube-logging/log-generator/formats/langpack"
+	"github.com/kube-logging/log-generator/formats/replay"
+	"github.com/kube-logging/log-generator/formats/sysloglike"
 	"github.com/kube-logging/log-generator/formats/web"
+	"github.com/kube-logging/log-generator/incidents"
 	"github.com/kube-logging/log-generator/log"
+	"github.com/kube-logging/log-generator/padding"
+	"github.com/kube-logging/log-generator/pii"
+	"github.com/kube-logging/log-generator/timestamps"
+	"github.com/kube-logging/log-generator/topology"
 	"github.com/kube-logging/log-generator/writers"
 )
 
@@ -47,25 +61,117 @@ type LogGen struct {
 	Randomise      bool                      `json:"randomise"`
 	ActiveRequests List                      `json:"active_requests"`
 	GolangLog      golang.GolangLogIntensity `json:"golang_log"`
-
-	m      sync.Mutex `json:"-"`
-	writer writers.LogWriter
+	Destinations   []string                  `json:"destinations"`
+	// Destinations used by streams and requests that do not name any.
+	DefaultDestinations []string `json:"default_destinations"`
+
+	m            sync.Mutex `json:"-"`
+	sendM        sync.Mutex // serializes the sends of the main loop and of the replay players
+	destinations map[string]writers.DestinationConfig
+	writers      map[string]writers.LogWriter
+	pii          *pii.Injector
+	padding      *padding.Padding
+	chaos        *chaos.Chaos
+	topology     *topology.Topology
+	// timestamp policies of the sources of events
+	timestamps map[string]*timestamps.Policy
+	backfill   *backfill.Backfill
+	// simulated time of the current tick when backfilling
+	now time.Time
 }
 
 type LogGenRequest struct {
-	Type    string `json:"type"`
-	Format  string `json:"format"`
-	Count   int    `json:"count"`
-	Framing bool   `json:"framing"`
+	Type         string   `json:"type"`
+	Format       string   `json:"format"`
+	Count        int      `json:"count"`
+	Framing      bool     `json:"framing"`
+	Destinations []string `json:"destinations,omitempty"`
 }
 
 func New() *LogGen {
-	return &LogGen{
+	l := &LogGen{
 		EventPerSec:    conf.Viper.GetInt("message.event-per-sec"),
 		BytePerSec:     conf.Viper.GetInt("message.byte-per-sec"),
 		Randomise:      conf.Viper.GetBool("message.randomise"),
 		ActiveRequests: List{list.New()},
+		destinations:   destinationsFromConfig(),
+	}
+	l.Destinations = l.destinationNames()
+
+	l.DefaultDestinations = destinationList(conf.Viper.GetString("message.destinations"))
+	if len(l.DefaultDestinations) == 0 {
+		l.DefaultDestinations = l.Destinations
+	}
+	if err := l.validateDestinations(l.DefaultDestinations); err != nil {
+		logger.Fatalf("invalid default destinations: %v", err)
+	}
+	for _, key := range streamDestinationKeys() {
+		if err := l.validateDestinations(destinationList(conf.Viper.GetString(key))); err != nil {
+			logger.Fatalf("invalid %s: %v", key, err)
+		}
+	}
+
+	if conf.Viper.GetBool("tracing.enabled") && conf.Viper.GetBool("tracing.requests") {
+		format := conf.Viper.GetString("tracing.format")
+		if _, err := log.NewLogTemplate(format, web.TemplateFS, web.SampleData()); err != nil {
+			logger.Fatalf("invalid tracing.format %q: %v", format, err)
+		}
+	}
+	if _, err := web.LoadTrafficModel(); err != nil {
+		logger.Fatalf("invalid web traffic model: %v", err)
+	}
+	if _, err := langpack.LoadMixer(); err != nil {
+		logger.Fatalf("invalid langpack config: %v", err)
+	}
+	if err := sysloglike.LoadProfile(); err != nil {
+		logger.Fatalf("invalid sysloglike config: %v", err)
+	}
+
+	in, err := pii.FromConfig()
+	if err != nil {
+		logger.Fatalf("invalid pii config: %v", err)
+	}
+	l.pii = in
+
+	p, err := padding.FromConfig()
+	if err != nil {
+		logger.Fatalf("invalid padding config: %v", err)
+	}
+	l.padding = p
+
+	c, err := chaos.FromConfig()
+	if err != nil {
+		logger.Fatalf("invalid chaos config: %v", err)
+	}
+	l.chaos = c
+
+	t, err := topology.FromConfig()
+	if err != nil {
+		logger.Fatalf("invalid topology config: %v", err)
 	}
+	if t != nil {
+		for service, destinations := range t.Destinations() {
+			if err := l.validateDestinations(destinations); err != nil {
+				logger.Fatalf("invalid destinations of service %q: %v", service, err)
+			}
+		}
+	}
+	l.topology = t
+
+	l.timestamps = timestampPolicies(t)
+
+	b, err := backfill.FromConfig()
+	if err != nil {
+		logger.Fatalf("invalid backfill config: %v", err)
+	}
+	l.backfill = b
+	if b != nil {
+		// incidents, PII ground truth and event times follow the simulated
+		// time, scheduled incidents start with the window
+		log.SetNow(b.Start())
+	}
+
+	return l
 }
 
 func (l *List) MarshalJSON() ([]byte, error) {
@@ -113,6 +219,11 @@ func (l *LogGen) PostHandler(ctx *gin.Context) {
 		return
 	}
 
+	if err := l.validateDestinations(lr.Destinations); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	l.m.Lock()
 	defer l.m.Unlock()
 
@@ -125,6 +236,10 @@ func (lr *LogGenRequest) Validate() error {
 	if _, exists := formats.FormatsByType()[lr.Type]; !exists {
 		return fmt.Errorf("type %q does not exist", lr.Type)
 	}
+	// sources played with their own timing are read by their player only
+	if lr.Type == "replay" && !slices.Contains(replay.Formats(), lr.Format) {
+		return fmt.Errorf("replay format %q does not exist, the formats are %s", lr.Format, strings.Join(replay.Formats(), ", "))
+	}
 
 	return nil
 }
@@ -200,9 +315,14 @@ func (l *LogGen) golangSet() error {
 	return nil
 }
 
+type pendingLog struct {
+	msg    log.Log
+	writer writers.LogWriter
+}
+
 func (l *LogGen) processRequests() bool {
 	l.m.Lock()
-	logs := make([]log.Log, 0, l.ActiveRequests.Len())
+	logs := make([]pendingLog, 0, l.ActiveRequests.Len())
 
 	e := l.ActiveRequests.Front()
 	for e != nil {
@@ -220,7 +340,7 @@ func (l *LogGen) processRequests() bool {
 			msg.SetFramed(true)
 		}
 
-		logs = append(logs, msg)
+		logs = append(logs, pendingLog{msg: l.wrap(l.stamp("requests", msg)), writer: l.writerFor(request.Destinations)})
 		e = e.Next()
 	}
 	l.m.Unlock()
@@ -229,8 +349,8 @@ func (l *LogGen) processRequests() bool {
 		return false
 	}
 
-	for _, msg := range logs {
-		l.writer.Send(msg)
+	for _, p := range logs {
+		l.send(p.writer, p.msg)
 	}
 
 	return true
@@ -245,40 +365,66 @@ func (l *LogGen) Run() {
 
 		// TODO implement main loop for custom formats?
 
-		var counter = 0
-		var ticker *jitterbug.Ticker
+		// the events counted towards message.count, sent by the loop and
+		// the replay players
+		var counter atomic.Int64
+		var ticks <-chan time.Time
 
-		// jitter := &jitterbug.Norm{Stdev: time.Millisecond * 300}
-		// TODO find a way to set Jitter from params
-		jitter := &jitterbug.Norm{}
+		if l.backfill != nil {
+			logger.Infof("backfilling %s", l.backfill)
+			ticks = l.backfill.Ticks()
+		} else {
+			var ticker *jitterbug.Ticker
 
-		if l.EventPerSec > 0 {
-			ticker = tickerForEvent(l.EventPerSec, jitter)
-		} else if l.BytePerSec > 0 {
-			ticker = tickerForByte(l.BytePerSec, jitter)
-		}
-		count := conf.Viper.GetInt("message.count")
+			// jitter := &jitterbug.Norm{Stdev: time.Millisecond * 300}
+			// TODO find a way to set Jitter from params
+			jitter := &jitterbug.Norm{}
 
-		if len(conf.Viper.GetString("destination.network")) != 0 {
-			l.writer = writers.NewNetworkWriter(conf.Viper.GetString("destination.network"), conf.Viper.GetString("destination.address"))
-		} else if len(conf.Viper.GetString("destination.file.path")) != 0 {
-			l.writer = writers.NewFileWriter(writers.FileLogWriterConfig{
-				Path:           conf.Viper.GetString("destination.file.path"),
-				Create:         conf.Viper.GetBool("destination.file.create"),
-				Append:         conf.Viper.GetBool("destination.file.append"),
-				FileMode:       os.FileMode(conf.Viper.GetUint32("destination.file.mode")),
-				DirMode:        os.FileMode(conf.Viper.GetUint32("destination.file.dir_mode")),
-				SyncAfterWrite: conf.Viper.GetBool("destination.file.sync"),
-			})
-		} else {
-			l.writer = writers.NewStdoutWriter()
+			if l.EventPerSec > 0 {
+				ticker = tickerForEvent(l.EventPerSec, jitter)
+			} else if l.BytePerSec > 0 {
+				ticker = tickerForByte(l.BytePerSec, jitter)
+			}
+			ticks = ticker.C
 		}
+		count := conf.Viper.GetInt("message.count")
 
+		l.openDestinations()
 		l.golangSet()
 
-		for range ticker.C {
+		nginxWriter := l.writerFor(destinationList(conf.Viper.GetString("nginx.destinations")))
+		apacheWriter := l.writerFor(destinationList(conf.Viper.GetString("apache.destinations")))
+		golangWriter := l.writerFor(destinationList(conf.Viper.GetString("golang.destinations")))
+		tracingWriter := l.writerFor(destinationList(conf.Viper.GetString("tracing.destinations")))
+		incidentsWriter := l.writerFor(destinationList(conf.Viper.GetString("incidents.destinations")))
+		streams := l.configStreams()
+		topologyWriters := map[string]writers.LogWriter{}
+		if l.topology != nil {
+			for service, destinations := range l.topology.Destinations() {
+				topologyWriters[service] = l.writerFor(destinations)
+			}
+		}
+
+		stop := make(chan struct{})
+		players := l.playReplays(stop, count, &counter)
+		played := make(chan struct{})
+		go func() {
+			players.Wait()
+			close(played)
+		}()
+
+		// sources other than the replay streams and players never end
+		endless := conf.Viper.GetBool("nginx.enabled") || conf.Viper.GetBool("apache.enabled") || conf.Viper.GetBool("golang.enabled") ||
+			conf.Viper.GetBool("tracing.enabled") && conf.Viper.GetBool("tracing.requests") || l.topology != nil
+		finite := !endless && (len(streams) > 0 || conf.Viper.GetBool("replay.enabled") && len(replay.Players()) > 0)
+
+		for now := range ticks {
+			l.now = now
+			if l.backfill != nil {
+				log.SetNow(now)
+			}
 			if conf.Viper.GetBool("nginx.enabled") {
-				l.sendIfCount(count, &counter, func() (log.Log, error) {
+				l.sendIfCount("nginx", nginxWriter, count, &counter, func() (log.Log, error) {
 					if l.Randomise {
 						return formats.NewRandomWeb("nginx", web.TemplateFS)
 					} else {
@@ -287,7 +433,7 @@ func (l *LogGen) Run() {
 				})
 			}
 			if conf.Viper.GetBool("apache.enabled") {
-				l.sendIfCount(count, &counter, func() (log.Log, error) {
+				l.sendIfCount("apache", apacheWriter, count, &counter, func() (log.Log, error) {
 					if l.Randomise {
 						return formats.NewRandomWeb("apache", web.TemplateFS)
 					} else {
@@ -296,19 +442,64 @@ func (l *LogGen) Run() {
 				})
 			}
 			if conf.Viper.GetBool("golang.enabled") {
-				l.sendIfCount(count, &counter, func() (log.Log, error) {
+				l.sendIfCount("golang", golangWriter, count, &counter, func() (log.Log, error) {
 					return formats.NewGolangRandom(l.GolangLog), nil
 				})
 			}
+			if conf.Viper.GetBool("tracing.enabled") && conf.Viper.GetBool("tracing.requests") {
+				events, err := formats.NewTracedRequest(conf.Viper.GetString("tracing.format"), l.GolangLog)
+				if err != nil {
+					logger.Panic(err)
+				}
+				for _, event := range events {
+					l.sendIfCount("tracing", tracingWriter, count, &counter, func() (log.Log, error) {
+						return event, nil
+					})
+				}
+			}
+			if l.topology != nil {
+				events, err := l.topology.Request()
+				if err != nil {
+					logger.Panic(err)
+				}
+				for _, event := range events {
+					l.sendIfCount(event.Service, topologyWriters[event.Service], count, &counter, func() (log.Log, error) {
+						return event.Log, nil
+					})
+				}
+			}
+			for _, event := range incidents.Default().Events() {
+				l.sendIfCount("incidents", incidentsWriter, count, &counter, func() (log.Log, error) {
+					return event, nil
+				})
+			}
+			for i := range streams {
+				stream := &streams[i]
+				l.sendIfCount(stream.logType, stream.writer, count, &counter, func() (log.Log, error) {
+					return stream.next(l.Randomise)
+				})
+			}
+			streams = slices.DeleteFunc(streams, func(s configStream) bool { return s.ended() })
 			pendingRequests := l.processRequests()
 
-			if !pendingRequests && count > 0 && !(counter < count) {
-				done <- true
+			if !pendingRequests && count > 0 && (counter.Load() >= int64(count) || finite && len(streams) == 0 && closed(played)) {
+				// the count is never reached once the replay streams and
+				// players, the only sources, have ended
 				break
 			}
 		}
 
-		l.writer.Close()
+		if l.backfill != nil {
+			logger.Infof("backfill of %s finished", l.backfill)
+		}
+		close(stop)
+		<-played
+
+		// writers waiting for acknowledgements need to finish before exiting
+		l.closeDestinations()
+		l.pii.Close()
+		incidents.Default().Close()
+		done <- true
 	}()
 
 	select {
@@ -320,13 +511,132 @@ func (l *LogGen) Run() {
 	}
 }
 
-func (l *LogGen) sendIfCount(count int, counter *int, f func() (log.Log, error)) {
-	if count == -1 || *counter < count {
-		n, err := f()
+// wrap pads the event to its configured size, corrupts it and injects
+// sensitive data. Chaos comes after padding so it can truncate padded events,
+// and sensitive data last so the ground truth only lists values that are in
+// the event.
+func (l *LogGen) wrap(msg log.Log) log.Log {
+	return l.pii.Wrap(l.chaos.Wrap(l.padding.Wrap(msg)))
+}
+
+// timestampPolicies returns the timestamp policies of the streams, the
+// services of the topology and the API requests, configured by their
+// [<section>.timestamps] config sections.
+func timestampPolicies(t *topology.Topology) map[string]*timestamps.Policy {
+	sections := map[string]string{}
+	for _, source := range []string{"nginx", "apache", "golang", "tracing", "incidents", "requests"} {
+		sections[source] = source
+	}
+	for logType := range formats.FormatsByType() {
+		sections[logType] = logType
+	}
+	if t != nil {
+		for service := range t.Destinations() {
+			sections[service] = "topology.services." + service
+		}
+	}
+
+	policies := map[string]*timestamps.Policy{}
+	for source, section := range sections {
+		p, err := timestamps.FromConfig(section)
 		if err != nil {
-			logger.Panic(err)
+			logger.Fatalf("invalid timestamps config of %q: %v", source, err)
 		}
-		l.writer.Send(n)
-		*counter++
+		policies[source] = p
+	}
+	return policies
+}
+
+// stamp sets the timestamp of an event of source with its timestamp policy.
+// When backfilling, events get the simulated time of the tick, shifted by the
+// policy if there is one. Events already timed after the tick, e.g. the hops
+// of a topology request started at the tick, keep their time.
+func (l *LogGen) stamp(source string, msg log.Log) log.Log {
+	if l.backfill == nil {
+		return l.timestamps[source].Apply(msg, time.Now())
+	}
+	if ts, ok := msg.(log.Timestamper); ok && ts.Timestamp().Before(l.now) {
+		ts.SetTime(l.now)
+	}
+	return l.timestamps[source].Apply(msg, l.now)
+}
+
+// sendIfCount sends an event of source unless the count is reached or an
+// incident silences the source or the host of the event.
+func (l *LogGen) sendIfCount(source string, w writers.LogWriter, count int, counter *atomic.Int64, f func() (log.Log, error)) {
+	if incidents.Silenced(source, "") || !reserve(counter, count) {
+		return
+	}
+	n, err := f()
+	if errors.Is(err, replay.ErrEnd) {
+		counter.Add(-1)
+		return
+	}
+	if err != nil {
+		logger.Panic(err)
+	}
+	if h, ok := n.(log.Hosted); ok && incidents.Silenced(source, h.Host()) {
+		counter.Add(-1)
+		return
+	}
+	l.send(w, l.wrap(l.stamp(source, n)))
+}
+
+// reserve counts an event towards count, -1 for no limit, unless the count is
+// reached.
+func reserve(counter *atomic.Int64, count int) bool {
+	if counter.Add(1) > int64(count) && count != -1 {
+		counter.Add(-1)
+		return false
+	}
+	return true
+}
+
+// closed reports whether c is closed.
+func closed(c <-chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+// send sends msg to w, one event at a time as not every writer can send
+// concurrently.
+func (l *LogGen) send(w writers.LogWriter, msg log.Log) {
+	l.sendM.Lock()
+	defer l.sendM.Unlock()
+
+	w.Send(msg)
+}
+
+// playReplays plays the replay sources with their own timing until they end
+// or stop is closed. Their lines are stamped with the real time, even when
+// backfilling, and count towards count like the events of the loop.
+func (l *LogGen) playReplays(stop <-chan struct{}, count int, counter *atomic.Int64) *sync.WaitGroup {
+	var players sync.WaitGroup
+	if !conf.Viper.GetBool("replay.enabled") {
+		return &players
+	}
+
+	for _, name := range replay.Players() {
+		w := l.writerFor(destinationList(conf.Viper.GetString("replay.sources." + name + ".destinations")))
+		players.Add(1)
+		go func() {
+			defer players.Done()
+			err := replay.Play(name, func(r *replay.Replay) {
+				if incidents.Silenced("replay", "") || !reserve(counter, count) {
+					return
+				}
+				l.send(w, l.wrap(l.timestamps["replay"].Apply(r, time.Now())))
+			}, stop)
+			if err != nil {
+				logger.Errorf("replay of %q failed: %v", name, err)
+				return
+			}
+			logger.Infof("replay of %q finished", name)
+		}()
 	}
+	return &players
 }
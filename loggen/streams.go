@@ -0,0 +1,113 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggen
+
+import (
+	"errors"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/Pallinder/go-randomdata"
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats"
+	"github.com/kube-logging/log-generator/formats/replay"
+	"github.com/kube-logging/log-generator/log"
+	"github.com/kube-logging/log-generator/writers"
+)
+
+// configStream emits a log type enabled by its own config section, e.g.
+//
+//	[exceptions]
+//	enabled = true
+//	formats = java,python
+//
+// Every event uses a random format of the listed ones, or of all the formats
+// of the type if none are listed. A replayed format leaves the stream at the
+// end of its source.
+type configStream struct {
+	logType string
+	formats []string
+	writer  writers.LogWriter
+}
+
+// configStreams returns the enabled streams, exiting if they list a format
+// that does not exist.
+func (l *LogGen) configStreams() []configStream {
+	available := formats.FormatsByType()
+
+	types := make([]string, 0, len(available))
+	for t := range available {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+
+	streams := []configStream{}
+	for _, t := range types {
+		if !conf.Viper.GetBool(t + ".enabled") {
+			continue
+		}
+
+		streamFormats := formatList(conf.Viper.GetString(t + ".formats"))
+		if len(streamFormats) == 0 {
+			streamFormats = available[t]
+		}
+		for _, f := range streamFormats {
+			if !slices.Contains(available[t], f) {
+				logger.Fatalf("invalid %s.formats: format %q does not exist, the formats of %s are %s",
+					t, f, t, strings.Join(available[t], ", "))
+			}
+		}
+		if len(streamFormats) == 0 {
+			continue
+		}
+
+		streams = append(streams, configStream{
+			logType: t,
+			formats: slices.Clone(streamFormats),
+			writer:  l.writerFor(destinationList(conf.Viper.GetString(t + ".destinations"))),
+		})
+	}
+
+	return streams
+}
+
+// formatList parses a comma separated list of formats, which unlike
+// destination names are case sensitive.
+func formatList(s string) []string {
+	formats := []string{}
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			formats = append(formats, f)
+		}
+	}
+	return formats
+}
+
+func (s *configStream) next(randomise bool) (log.Log, error) {
+	f := randomdata.StringSample(s.formats...)
+	msg, err := formats.LogFactory(s.logType, f, randomise)
+	if errors.Is(err, replay.ErrEnd) {
+		s.formats = slices.DeleteFunc(s.formats, func(g string) bool { return g == f })
+	}
+	return msg, err
+}
+
+// ended reports whether all the formats of s ended.
+func (s *configStream) ended() bool {
+	return len(s.formats) == 0
+}
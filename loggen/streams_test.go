@@ -0,0 +1,85 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loggen
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats/replay"
+	"github.com/kube-logging/log-generator/writers"
+)
+
+func TestConfigStreams(t *testing.T) {
+	conf.Init()
+	conf.Viper.Set("structured.enabled", true)
+	conf.Viper.Set("structured.formats", "zap, logrus")
+
+	l := &LogGen{writers: map[string]writers.LogWriter{}}
+	streams := l.configStreams()
+	if len(streams) != 1 || streams[0].logType != "structured" || len(streams[0].formats) != 2 {
+		t.Fatalf("Expected the structured stream with 2 formats, got %+v", streams)
+	}
+
+	exited := false
+	logger.StandardLogger().ExitFunc = func(int) { exited = true }
+	defer func() { logger.StandardLogger().ExitFunc = nil }()
+
+	conf.Viper.Set("structured.formats", "zap,missing")
+	l.configStreams()
+	if !exited {
+		t.Error("Expected a format that does not exist to stop the generator")
+	}
+}
+
+func TestReplayStream(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.log")
+	if err := os.WriteFile(path, []byte("one\ntwo\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	conf.Init()
+	conf.Viper.Set("replay.enabled", true)
+	conf.Viper.Set("replay.sources.ticked.path", path)
+	conf.Viper.Set("replay.sources.ticked.timing", replay.TimingTick)
+	conf.Viper.Set("replay.sources.played.path", path)
+	conf.Viper.Set("replay.sources.played.timing", replay.TimingScale)
+
+	l := &LogGen{writers: map[string]writers.LogWriter{}}
+	streams := l.configStreams()
+	if len(streams) != 1 || streams[0].logType != "replay" {
+		t.Fatalf("Expected the replay stream, got %+v", streams)
+	}
+	s := &streams[0]
+	for i := 0; i < 2; i++ {
+		if _, err := s.next(false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := s.next(false); !errors.Is(err, replay.ErrEnd) || !s.ended() {
+		t.Errorf("Expected the stream to end with its source, got %v", err)
+	}
+
+	if err := (&LogGenRequest{Type: "replay", Format: "ticked"}).Validate(); err != nil {
+		t.Errorf("Expected a tick source to be valid, got %v", err)
+	}
+	if err := (&LogGenRequest{Type: "replay", Format: "played"}).Validate(); err == nil {
+		t.Error("Expected a played source to be rejected")
+	}
+}
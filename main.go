@@ -26,6 +26,7 @@ import (
 	log "github.com/sirupsen/logrus"
 
 	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/incidents"
 	"github.com/kube-logging/log-generator/loggen"
 	"github.com/kube-logging/log-generator/metrics"
 	"github.com/kube-logging/log-generator/stress"
@@ -126,6 +127,11 @@ func main() {
 
 	var s State
 	s.Loggen = loggen.New()
+	if s.Loggen.StdoutBuffered() {
+		// logs would end up in the middle of compressed and batched events
+		log.SetOutput(os.Stderr)
+		gin.DefaultWriter = os.Stderr
+	}
 	s.LogLevel.Level = log.GetLevel().String()
 
 	go func() {
@@ -144,6 +150,8 @@ func main() {
 		api.PATCH("/cpu", s.Cpu.PatchHandler)
 		api.GET("/log_level", s.logLevelGetHandler)
 		api.PATCH("/log_level", s.logLevelPatchHandler)
+		api.GET("/incidents", incidents.Default().GetHandler)
+		api.POST("/incidents", incidents.Default().PostHandler)
 		api.GET("/golang", s.Loggen.GolangGetHandler)
 		api.PATCH("/golang", s.Loggen.GolangPatchHandler)
 		api.GET("exceptions/go", exceptionsGoCall)
@@ -37,6 +37,48 @@ var (
 		Help: "The total bytes of events",
 	},
 		[]string{"type", "severity"})
+	EventEmittedRunes = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "loggen_event_runes_total",
+		Help: "The total characters of events, multi-byte characters count once",
+	},
+		[]string{"type", "severity"})
+
+	WriterResentEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "loggen_writer_resent_events_total",
+		Help: "The total number of unacknowledged events sent again after a reconnect, the receiver may see them as duplicates",
+	},
+		[]string{"writer"})
+
+	WriterRejectedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "loggen_writer_rejected_events_total",
+		Help: "The total number of events rejected by the receiver",
+	},
+		[]string{"writer"})
+
+	WriterUnexpectedAcks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "loggen_writer_unexpected_acks_total",
+		Help: "The total number of acknowledgements for events that were not waiting for one",
+	},
+		[]string{"writer"})
+
+	ChaosCorruptedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "loggen_chaos_corrupted_events_total",
+		Help: "The total number of events corrupted by the chaos layer",
+	},
+		[]string{"type", "corruption"})
+
+	PIIInjectedValues = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "loggen_pii_injected_values_total",
+		Help: "The total number of synthetic sensitive values injected into events",
+	},
+		[]string{"type", "kind"})
+
+	IncidentActive = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "loggen_incident_active",
+		Help: "1 while an injected incident is active, 0 after it ended",
+	},
+		[]string{"id", "kind", "target"})
+
 	GeneratedLoad = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "generated_load",
 		Help: "Generated load",
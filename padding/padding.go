@@ -0,0 +1,246 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package padding
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// Config configures the size distribution of events.
+type Config struct {
+	// Distribution is fixed, uniform, normal, lognormal, histogram or large.
+	Distribution string
+	// Size is the size of fixed, the mean of normal and the median of
+	// lognormal distributions.
+	Size string
+	// StdDev is the standard deviation of normal distributions.
+	StdDev string
+	// Sigma is the standard deviation of the logarithm of lognormal sizes.
+	Sigma float64
+	// Min and Max bound the sizes of every distribution.
+	Min string
+	Max string
+	// Histogram lists "size=weight" entries of histogram distributions.
+	Histogram string
+	// LargeSizes lists the sizes of the large distribution, picked with the
+	// same weight.
+	LargeSizes string
+	// Seed makes the sizes and padding reproducible, a random seed is used
+	// if 0.
+	Seed int64
+}
+
+func ConfigFromViper() Config {
+	return Config{
+		Distribution: conf.Viper.GetString("padding.distribution"),
+		Size:         conf.Viper.GetString("padding.size"),
+		StdDev:       conf.Viper.GetString("padding.stddev"),
+		Sigma:        conf.Viper.GetFloat64("padding.sigma"),
+		Min:          conf.Viper.GetString("padding.min"),
+		Max:          conf.Viper.GetString("padding.max"),
+		Histogram:    conf.Viper.GetString("padding.histogram"),
+		LargeSizes:   conf.Viper.GetString("padding.large_sizes"),
+		Seed:         conf.Viper.GetInt64("padding.seed"),
+	}
+}
+
+// Padding pads the events it wraps to sizes drawn from a distribution.
+type Padding struct {
+	distribution string
+	size         float64
+	stdDev       float64
+	sigma        float64
+	min          int
+	max          int
+	sizes        []int
+	weights      []int
+	total        int
+
+	m sync.Mutex
+	r *rand.Rand
+}
+
+// FromConfig returns the Padding configured by the [padding] config section,
+// or nil if it is disabled.
+func FromConfig() (*Padding, error) {
+	if !conf.Viper.GetBool("padding.enabled") {
+		return nil, nil
+	}
+	return New(ConfigFromViper())
+}
+
+func New(config Config) (*Padding, error) {
+	p := &Padding{distribution: config.Distribution, sigma: config.Sigma}
+
+	var err error
+	if p.min, err = ParseSize(config.Min); err != nil {
+		return nil, fmt.Errorf("min: %w", err)
+	}
+	if p.max, err = ParseSize(config.Max); err != nil {
+		return nil, fmt.Errorf("max: %w", err)
+	}
+	if p.max < p.min {
+		return nil, fmt.Errorf("max %d is less than min %d", p.max, p.min)
+	}
+
+	switch config.Distribution {
+	case "fixed", "normal", "lognormal":
+		size, err := ParseSize(config.Size)
+		if err != nil {
+			return nil, fmt.Errorf("size: %w", err)
+		}
+		p.size = float64(size)
+		if config.Distribution == "normal" {
+			stdDev, err := ParseSize(config.StdDev)
+			if err != nil {
+				return nil, fmt.Errorf("stddev: %w", err)
+			}
+			p.stdDev = float64(stdDev)
+		}
+		if config.Distribution == "lognormal" && config.Sigma <= 0 {
+			return nil, fmt.Errorf("sigma must be positive, got %v", config.Sigma)
+		}
+	case "uniform":
+	case "histogram", "large":
+		spec := config.Histogram
+		if config.Distribution == "large" {
+			spec = config.LargeSizes
+		}
+		if err := p.parseHistogram(spec); err != nil {
+			return nil, fmt.Errorf("%s: %w", config.Distribution, err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown distribution %q", config.Distribution)
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	p.r = rand.New(rand.NewSource(seed))
+
+	return p, nil
+}
+
+func (p *Padding) parseHistogram(spec string) error {
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		s, weight := item, 1
+		if n, w, ok := strings.Cut(item, "="); ok {
+			var err error
+			if weight, err = strconv.Atoi(strings.TrimSpace(w)); err != nil || weight < 0 {
+				return fmt.Errorf("invalid weight of size %q", n)
+			}
+			s = n
+		}
+		size, err := ParseSize(s)
+		if err != nil {
+			return err
+		}
+		p.sizes = append(p.sizes, size)
+		p.weights = append(p.weights, weight)
+		p.total += weight
+	}
+	if p.total == 0 {
+		return fmt.Errorf("no sizes with positive weight")
+	}
+	return nil
+}
+
+// ParseSize parses a number of bytes with an optional B, K, KB, KiB, M, MB
+// or MiB unit. K and M are binary units.
+func ParseSize(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	units := []struct {
+		suffix     string
+		multiplier float64
+	}{{"KiB", 1 << 10}, {"MiB", 1 << 20}, {"KB", 1e3}, {"MB", 1e6}, {"K", 1 << 10}, {"M", 1 << 20}, {"B", 1}}
+
+	multiplier := 1.0
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			s, multiplier = strings.TrimSpace(strings.TrimSuffix(s, u.suffix)), u.multiplier
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+	return int(n * multiplier), nil
+}
+
+// next returns the size of the next event.
+func (p *Padding) next() int {
+	var size float64
+	switch p.distribution {
+	case "fixed":
+		size = p.size
+	case "uniform":
+		size = float64(p.min + p.r.Intn(p.max-p.min+1))
+	case "normal":
+		size = p.size + p.stdDev*p.r.NormFloat64()
+	case "lognormal":
+		size = p.size * math.Exp(p.sigma*p.r.NormFloat64())
+	default:
+		n := p.r.Intn(p.total)
+		for i, w := range p.weights {
+			if n < w {
+				size = float64(p.sizes[i])
+				break
+			}
+			n -= w
+		}
+	}
+	return int(math.Max(float64(p.min), math.Min(float64(p.max), size)))
+}
+
+// Wrap returns l padded to the size of the next event. A nil Padding returns
+// l unchanged.
+func (p *Padding) Wrap(l log.Log) log.Log {
+	if p == nil || l == nil {
+		return l
+	}
+
+	p.m.Lock()
+	defer p.m.Unlock()
+	return &padded{Log: l, size: p.next(), seed: p.r.Int63()}
+}
+
+// padded is an event padded with text when it is rendered. Events longer than
+// size are not truncated.
+type padded struct {
+	log.Log
+	size int
+	seed int64
+}
+
+func (p *padded) String() (string, float64) {
+	msg, _ := p.Log.String()
+	msg = pad(rand.New(rand.NewSource(p.seed)), msg, p.size)
+	return msg, float64(len(msg))
+}
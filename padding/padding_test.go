@@ -0,0 +1,80 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package padding
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/kube-logging/log-generator/log/logtest"
+)
+
+func TestPadding(t *testing.T) {
+	p, err := New(Config{Distribution: "large", LargeSizes: "16KiB,64KiB,1MiB", Min: "0", Max: "16MiB", Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := []string{
+		`{"level":"info","msg":"request completed"}`,
+		"{}\n",
+		`<Event><System><EventID>4624</EventID></System></Event>`,
+		`127.0.0.1 - - [04/Mar/2026:10:11:12 +0000] "GET / HTTP/1.1" 200 612`,
+	}
+	for _, event := range events {
+		for i := 0; i < 5; i++ {
+			l := p.Wrap(logtest.New(event))
+			msg, size := l.String()
+			if expected := l.(*padded).size; len(msg) != expected || size != float64(expected) {
+				t.Errorf("Expected %d bytes, got %d, %q", expected, len(msg), event)
+			}
+
+			switch event[0] {
+			case '{':
+				if !json.Valid([]byte(msg)) {
+					t.Errorf("Invalid JSON, %q", msg[:100])
+				}
+			case '<':
+				if err := xml.Unmarshal([]byte(msg), new(struct{})); err != nil {
+					t.Errorf("Invalid XML, %v", err)
+				}
+			}
+		}
+	}
+}
+
+func TestDistributions(t *testing.T) {
+	configs := []Config{
+		{Distribution: "fixed", Size: "2KiB", Min: "0", Max: "1MiB"},
+		{Distribution: "uniform", Min: "100", Max: "200"},
+		{Distribution: "normal", Size: "1K", StdDev: "100", Min: "512", Max: "2K"},
+		{Distribution: "lognormal", Size: "1K", Sigma: 2, Min: "64", Max: "64K"},
+		{Distribution: "histogram", Histogram: "100=9,1M=1", Min: "0", Max: "1M"},
+	}
+	for _, config := range configs {
+		p, err := New(config)
+		if err != nil {
+			t.Fatalf("Failed to create padding, distribution=%q, %v", config.Distribution, err)
+		}
+		min, _ := ParseSize(config.Min)
+		max, _ := ParseSize(config.Max)
+		for i := 0; i < 1000; i++ {
+			if size := p.next(); size < min || size > max {
+				t.Fatalf("Size %d out of range, distribution=%q", size, config.Distribution)
+			}
+		}
+	}
+}
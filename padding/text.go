@@ -0,0 +1,88 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package padding
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// sentences are the text events are padded with. They need no escaping in
+// JSON strings and XML comments.
+var sentences = []string{
+	"The request was processed by the upstream service after a retry.",
+	"Connection pool statistics: active 12, idle 4, waiting 0.",
+	"Cache entry refreshed from the primary store.",
+	"User session attributes were updated with the latest preferences.",
+	"Payload validation completed without warnings.",
+	"The scheduler moved the job to the next available worker.",
+	"Response headers included cache control and content type.",
+	"Background compaction reclaimed space in the storage segment.",
+	"Configuration values were loaded from the mounted volume.",
+	"The downstream consumer acknowledged the batch.",
+	"Feature flags evaluated for the tenant: search v2, new checkout.",
+	"TLS session resumed with the negotiated cipher suite.",
+}
+
+// text returns realistic text of exactly n bytes.
+func text(r *rand.Rand, n int) string {
+	var b strings.Builder
+	b.Grow(n + 80)
+	for b.Len() < n {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(sentences[r.Intn(len(sentences))])
+	}
+	return b.String()[:n]
+}
+
+// pad pads msg to size bytes. JSON objects get an additional field and XML
+// documents a comment before their closing tag, so they remain valid; other
+// events get text appended to them.
+func pad(r *rand.Rand, msg string, size int) string {
+	trimmed := strings.TrimRight(msg, "\n")
+
+	switch {
+	case strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}"):
+		const field = `,"padding":""`
+		body := strings.TrimSpace(trimmed[:len(trimmed)-1])
+		prefix := field
+		if body == "{" {
+			prefix = field[1:]
+		}
+		tail := msg[len(trimmed):]
+		n := size - len(body) - len(prefix) - 1 - len(tail)
+		if n <= 0 {
+			return msg
+		}
+		return body + prefix[:len(prefix)-1] + text(r, n) + `"}` + tail
+
+	case strings.HasPrefix(trimmed, "<") && strings.HasSuffix(trimmed, ">"):
+		i := strings.LastIndex(trimmed, "</")
+		n := size - len(msg) - len("<!--  -->")
+		if i < 0 || n <= 0 {
+			return msg
+		}
+		return trimmed[:i] + "<!-- " + text(r, n) + " -->" + msg[i:]
+
+	default:
+		n := size - len(msg) - 1
+		if n <= 0 {
+			return msg
+		}
+		return trimmed + " " + text(r, n) + msg[len(trimmed):]
+	}
+}
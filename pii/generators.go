@@ -0,0 +1,217 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pii
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// kind is a type of sensitive data. value generates the sensitive value, key
+// is the field name of JSON events and contexts are the texts of other events
+// the value is embedded in.
+type kind struct {
+	key      string
+	value    func(r *rand.Rand) string
+	contexts []string
+}
+
+var kinds = map[string]kind{
+	"email":          {key: "email", value: email, contexts: []string{"user=%s", "sending notification to %s", "from=<%s>"}},
+	"phone":          {key: "phone", value: phone, contexts: []string{"phone=%s", "SMS sent to %s"}},
+	"credit_card":    {key: "card_number", value: creditCard, contexts: []string{"card=%s", "payment declined for card %s"}},
+	"iban":           {key: "iban", value: iban, contexts: []string{"iban=%s", "refund to account %s"}},
+	"jwt":            {key: "authorization", value: jwt, contexts: []string{"Authorization: Bearer %s", "token=%s"}},
+	"aws_access_key": {key: "aws_access_key_id", value: awsAccessKey, contexts: []string{"aws_access_key_id=%s", "using credentials %s"}},
+	"aws_secret_key": {key: "aws_secret_access_key", value: awsSecretKey, contexts: []string{"aws_secret_access_key=%s"}},
+	"url_password":   {key: "url", value: urlPassword, contexts: []string{"connecting to %s", "upstream=%s"}},
+	"ssn":            {key: "ssn", value: ssn, contexts: []string{"ssn=%s", "verified SSN %s"}},
+}
+
+var (
+	firstNames = []string{"jane", "john", "maria", "wei", "fatima", "olga", "kenji", "amara", "lucas", "priya"}
+	lastNames  = []string{"doe", "smith", "garcia", "chen", "haddad", "ivanova", "tanaka", "okafor", "silva", "sharma"}
+	domains    = []string{"example.com", "example.org", "example.net", "mail.example.com"}
+)
+
+func email(r *rand.Rand) string {
+	first, last := firstNames[r.Intn(len(firstNames))], lastNames[r.Intn(len(lastNames))]
+	switch r.Intn(3) {
+	case 0:
+		return fmt.Sprintf("%s.%s@%s", first, last, domains[r.Intn(len(domains))])
+	case 1:
+		return fmt.Sprintf("%s%s%d@%s", first[:1], last, r.Intn(100), domains[r.Intn(len(domains))])
+	default:
+		return fmt.Sprintf("%s+%s@%s", first, []string{"shop", "news", "test"}[r.Intn(3)], domains[r.Intn(len(domains))])
+	}
+}
+
+// phone returns numbers of the ranges reserved for fiction where they exist.
+func phone(r *rand.Rand) string {
+	switch r.Intn(3) {
+	case 0:
+		return fmt.Sprintf("+1 %03d-555-01%02d", 201+r.Intn(700), r.Intn(100))
+	case 1:
+		return fmt.Sprintf("+44 7700 900%03d", r.Intn(1000))
+	default:
+		return fmt.Sprintf("+36 %d %03d %04d", []int{20, 30, 70}[r.Intn(3)], r.Intn(1000), r.Intn(10000))
+	}
+}
+
+func digits(r *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = byte('0' + r.Intn(10))
+	}
+	return string(b)
+}
+
+// luhn returns the check digit that makes number valid.
+func luhn(number string) int {
+	sum := 0
+	for i := len(number) - 1; i >= 0; i-- {
+		d := int(number[i] - '0')
+		if (len(number)-i)%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
+
+// creditCard returns a Luhn valid Visa, Mastercard or American Express
+// number, sometimes grouped with spaces or dashes.
+func creditCard(r *rand.Rand) string {
+	var number string
+	switch r.Intn(3) {
+	case 0:
+		number = "4" + digits(r, 14)
+	case 1:
+		number = strconv.Itoa(51+r.Intn(5)) + digits(r, 13)
+	default:
+		number = []string{"34", "37"}[r.Intn(2)] + digits(r, 12)
+	}
+	number += strconv.Itoa(luhn(number))
+
+	if len(number) == 16 && r.Intn(2) == 0 {
+		sep := []string{" ", "-"}[r.Intn(2)]
+		return strings.Join([]string{number[0:4], number[4:8], number[8:12], number[12:16]}, sep)
+	}
+	return number
+}
+
+// ibanFormats are the country codes of IBANs and the formats of their BBAN,
+// 'n' for digits and 'a' for upper case letters.
+var ibanFormats = map[string]string{
+	"DE": "nnnnnnnnnnnnnnnnnn",
+	"GB": "aaaannnnnnnnnnnnnn",
+	"FR": "nnnnnnnnnnnnnnnnnnnnnnn",
+	"HU": "nnnnnnnnnnnnnnnnnnnnnnnn",
+	"NL": "aaaannnnnnnnnn",
+}
+
+var ibanCountries = []string{"DE", "GB", "FR", "HU", "NL"}
+
+// iban returns an IBAN with valid check digits, sometimes in its print format.
+func iban(r *rand.Rand) string {
+	country := ibanCountries[r.Intn(len(ibanCountries))]
+
+	bban := make([]byte, len(ibanFormats[country]))
+	for i, c := range ibanFormats[country] {
+		if c == 'a' {
+			bban[i] = byte('A' + r.Intn(26))
+		} else {
+			bban[i] = byte('0' + r.Intn(10))
+		}
+	}
+
+	// ISO 13616: the check digits make the number mod 97 equal to 1
+	remainder := 0
+	for _, c := range string(bban) + country + "00" {
+		v := int(c - '0')
+		if c >= 'A' {
+			v = int(c-'A') + 10
+		}
+		if v >= 10 {
+			remainder = (remainder*100 + v) % 97
+		} else {
+			remainder = (remainder*10 + v) % 97
+		}
+	}
+	number := fmt.Sprintf("%s%02d%s", country, 98-remainder, bban)
+
+	if r.Intn(2) == 0 {
+		var groups []string
+		for i := 0; i < len(number); i += 4 {
+			groups = append(groups, number[i:min(i+4, len(number))])
+		}
+		return strings.Join(groups, " ")
+	}
+	return number
+}
+
+func jwt(r *rand.Rand) string {
+	enc := base64.RawURLEncoding
+	header, _ := json.Marshal(map[string]string{"alg": "HS256", "typ": "JWT"})
+	iat := 1760000000 + r.Intn(10000000)
+	payload, _ := json.Marshal(map[string]interface{}{
+		"sub":   fmt.Sprintf("user-%d", r.Intn(100000)),
+		"email": email(r),
+		"iat":   iat,
+		"exp":   iat + 3600,
+	})
+	signature := make([]byte, 32)
+	r.Read(signature)
+	return enc.EncodeToString(header) + "." + enc.EncodeToString(payload) + "." + enc.EncodeToString(signature)
+}
+
+func randomString(r *rand.Rand, chars string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = chars[r.Intn(len(chars))]
+	}
+	return string(b)
+}
+
+func awsAccessKey(r *rand.Rand) string {
+	return []string{"AKIA", "ASIA"}[r.Intn(2)] + randomString(r, "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567", 16)
+}
+
+func awsSecretKey(r *rand.Rand) string {
+	return randomString(r, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789+/", 40)
+}
+
+func urlPassword(r *rand.Rand) string {
+	user := firstNames[r.Intn(len(firstNames))]
+	password := randomString(r, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789!*-_", 8+r.Intn(12))
+	return fmt.Sprintf("%s://%s:%s@%s", []string{"https", "postgres", "amqp", "redis"}[r.Intn(4)], user, password,
+		[]string{"db.internal.example.com:5432/orders", "mq.example.com:5672/", "api.example.com/v1/export", "cache.example.com:6379/0"}[r.Intn(4)])
+}
+
+// ssn returns US social security numbers in the valid ranges.
+func ssn(r *rand.Rand) string {
+	area := 1 + r.Intn(899)
+	if area == 666 {
+		area = 667
+	}
+	return fmt.Sprintf("%03d-%02d-%04d", area, 1+r.Intn(99), 1+r.Intn(9999))
+}
@@ -0,0 +1,300 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pii
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/log"
+	"github.com/kube-logging/log-generator/metrics"
+)
+
+// Config configures the injection of sensitive data.
+type Config struct {
+	// Rate is the fraction of events with sensitive data.
+	Rate float64
+	// Kinds lists "kind=weight" entries, all kinds are used with the same
+	// weight if empty.
+	Kinds string
+	// PerEvent is the maximum number of values injected into an event.
+	PerEvent int
+	// GroundTruthFile is the file the injected values are recorded in, one
+	// JSON line per event.
+	GroundTruthFile string
+	// Seed makes the injected values reproducible, a random seed is used if 0.
+	Seed int64
+}
+
+func ConfigFromViper() Config {
+	return Config{
+		Rate:            conf.Viper.GetFloat64("pii.rate"),
+		Kinds:           conf.Viper.GetString("pii.kinds"),
+		PerEvent:        conf.Viper.GetInt("pii.per_event"),
+		GroundTruthFile: conf.Viper.GetString("pii.ground_truth_file"),
+		Seed:            conf.Viper.GetInt64("pii.seed"),
+	}
+}
+
+// Kinds returns the names of the kinds of sensitive data.
+func Kinds() []string {
+	names := make([]string, 0, len(kinds))
+	for name := range kinds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Injection is a sensitive value injected into an event.
+type Injection struct {
+	Kind string `json:"kind"`
+	// Key is the JSON field or XML Data element of the value, suffixed when
+	// an event has several values of the same kind.
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	// text is the value in its context
+	text string
+}
+
+// SeqKey is the field of the events with sensitive data holding the Seq of
+// their ground truth record.
+const SeqKey = "pii_seq"
+
+// GroundTruth is the record of the sensitive data of an event.
+type GroundTruth struct {
+	Seq      uint64      `json:"seq"`
+	Time     time.Time   `json:"time"`
+	Type     string      `json:"type"`
+	Injected []Injection `json:"injected"`
+}
+
+// Injector injects sensitive data into a fraction of the events it wraps.
+type Injector struct {
+	rate     float64
+	perEvent int
+	names    []string
+	weights  []int
+	total    int
+
+	m           sync.Mutex
+	r           *rand.Rand
+	seq         uint64
+	groundTruth io.WriteCloser
+}
+
+// FromConfig returns the Injector configured by the [pii] config section, or
+// nil if it is disabled.
+func FromConfig() (*Injector, error) {
+	if !conf.Viper.GetBool("pii.enabled") {
+		return nil, nil
+	}
+	return New(ConfigFromViper())
+}
+
+func New(config Config) (*Injector, error) {
+	if config.Rate < 0 || config.Rate > 1 {
+		return nil, fmt.Errorf("rate must be between 0 and 1, got %v", config.Rate)
+	}
+	if config.PerEvent < 1 {
+		return nil, fmt.Errorf("per event must be at least 1, got %d", config.PerEvent)
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	in := &Injector{
+		rate:     config.Rate,
+		perEvent: config.PerEvent,
+		r:        rand.New(rand.NewSource(seed)),
+	}
+
+	spec := config.Kinds
+	if strings.TrimSpace(spec) == "" {
+		spec = strings.Join(Kinds(), ",")
+	}
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, weight := item, 1
+		if n, w, ok := strings.Cut(item, "="); ok {
+			var err error
+			if weight, err = strconv.Atoi(strings.TrimSpace(w)); err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid weight of kind %q", n)
+			}
+			name = strings.TrimSpace(n)
+		}
+		if _, ok := kinds[name]; !ok {
+			return nil, fmt.Errorf("unknown kind %q", name)
+		}
+		in.names = append(in.names, name)
+		in.weights = append(in.weights, weight)
+		in.total += weight
+	}
+	if in.total == 0 {
+		return nil, fmt.Errorf("no kinds with positive weight")
+	}
+
+	if config.GroundTruthFile != "" {
+		f, err := os.OpenFile(config.GroundTruthFile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("opening ground truth file: %w", err)
+		}
+		in.groundTruth = f
+	}
+
+	return in, nil
+}
+
+// Wrap returns l, or l with sensitive data for the configured rate of the
+// events. The injected values are recorded in the ground truth file and
+// counted by kind. A nil Injector returns l unchanged.
+func (in *Injector) Wrap(l log.Log) log.Log {
+	if in == nil || l == nil {
+		return l
+	}
+
+	in.m.Lock()
+	defer in.m.Unlock()
+
+	if in.r.Float64() >= in.rate {
+		return l
+	}
+
+	in.seq++
+	truth := GroundTruth{Seq: in.seq, Time: log.Now().UTC(), Type: l.Labels()["type"]}
+	keys := map[string]int{}
+	for n := 1 + in.r.Intn(in.perEvent); n > 0; n-- {
+		name := in.pick()
+		k := kinds[name]
+		value := k.value(in.r)
+
+		key := k.key
+		if keys[k.key]++; keys[k.key] > 1 {
+			key += "_" + strconv.Itoa(keys[k.key])
+		}
+		truth.Injected = append(truth.Injected, Injection{
+			Kind:  name,
+			Key:   key,
+			Value: value,
+			text:  fmt.Sprintf(k.contexts[in.r.Intn(len(k.contexts))], value),
+		})
+		metrics.PIIInjectedValues.With(map[string]string{"type": truth.Type, "kind": name}).Inc()
+	}
+
+	if in.groundTruth != nil {
+		line, err := json.Marshal(truth)
+		if err == nil {
+			_, err = in.groundTruth.Write(append(line, '\n'))
+		}
+		if err != nil {
+			logger.Errorf("error writing pii ground truth: %v", err)
+		}
+	}
+
+	return &injected{Log: l, seq: truth.Seq, injected: truth.Injected}
+}
+
+func (in *Injector) pick() string {
+	n := in.r.Intn(in.total)
+	for i, w := range in.weights {
+		if n < w {
+			return in.names[i]
+		}
+		n -= w
+	}
+	return in.names[len(in.names)-1]
+}
+
+// Close closes the ground truth file.
+func (in *Injector) Close() {
+	if in == nil || in.groundTruth == nil {
+		return
+	}
+
+	in.m.Lock()
+	defer in.m.Unlock()
+	in.groundTruth.Close()
+	in.groundTruth = nil
+}
+
+// injected is an event with sensitive data. JSON objects get a field and XML
+// documents a Data element for each value before their closing tag, other
+// events get the values in their context appended. The Seq of the ground
+// truth record comes last, as the SeqKey field.
+type injected struct {
+	log.Log
+	seq      uint64
+	injected []Injection
+}
+
+func (e *injected) String() (string, float64) {
+	msg, _ := e.Log.String()
+	trimmed := strings.TrimRight(msg, "\n")
+	end := strings.LastIndex(trimmed, "</")
+
+	var b strings.Builder
+	switch {
+	case strings.HasPrefix(trimmed, "{") && strings.HasSuffix(trimmed, "}"):
+		body := strings.TrimSpace(trimmed[:len(trimmed)-1])
+		b.WriteString(body)
+		for i, v := range e.injected {
+			if i > 0 || body != "{" {
+				b.WriteByte(',')
+			}
+			key, _ := json.Marshal(v.Key)
+			value, _ := json.Marshal(v.Value)
+			fmt.Fprintf(&b, "%s:%s", key, value)
+		}
+		fmt.Fprintf(&b, `,"%s":%d}`, SeqKey, e.seq)
+		b.WriteString(msg[len(trimmed):])
+
+	case strings.HasPrefix(trimmed, "<") && strings.HasSuffix(trimmed, ">") && end >= 0:
+		b.WriteString(trimmed[:end])
+		for _, v := range e.injected {
+			fmt.Fprintf(&b, `<Data Name="%s">`, v.Key)
+			xml.EscapeText(&b, []byte(v.Value))
+			b.WriteString("</Data>")
+		}
+		fmt.Fprintf(&b, `<Data Name="%s">%d</Data>`, SeqKey, e.seq)
+		b.WriteString(msg[end:])
+
+	default:
+		b.WriteString(trimmed)
+		for _, v := range e.injected {
+			b.WriteString(" " + v.text)
+		}
+		fmt.Fprintf(&b, " %s=%d", SeqKey, e.seq)
+		b.WriteString(msg[len(trimmed):])
+	}
+
+	msg = b.String()
+	return msg, float64(len(msg))
+}
@@ -0,0 +1,138 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pii
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kube-logging/log-generator/log/logtest"
+)
+
+func TestChecksums(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		card := strings.NewReplacer(" ", "", "-", "").Replace(creditCard(r))
+		if luhn(card[:len(card)-1]) != int(card[len(card)-1]-'0') {
+			t.Errorf("Invalid Luhn check digit, %q", card)
+		}
+
+		number := strings.ReplaceAll(iban(r), " ", "")
+		digits := ""
+		for _, c := range number[4:] + number[:4] {
+			if c >= 'A' {
+				digits += big.NewInt(int64(c-'A') + 10).String()
+			} else {
+				digits += string(c)
+			}
+		}
+		n, _ := new(big.Int).SetString(digits, 10)
+		if n.Mod(n, big.NewInt(97)).Int64() != 1 {
+			t.Errorf("Invalid IBAN check digits, %q", number)
+		}
+	}
+}
+
+func TestInjection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "truth.jsonl")
+	in, err := New(Config{Rate: 1, PerEvent: 3, GroundTruthFile: path, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := []string{
+		`{"level":"info","msg":"request completed"}`,
+		`<Event><System><EventID>4624</EventID></System></Event>`,
+		`127.0.0.1 - - [04/Mar/2026:10:11:12 +0000] "GET / HTTP/1.1" 200 612`,
+	}
+	var rendered []string
+	for _, event := range events {
+		msg, _ := in.Wrap(logtest.New(event)).String()
+		switch event[0] {
+		case '{':
+			if !json.Valid([]byte(msg)) {
+				t.Errorf("Invalid JSON, %q", msg)
+			}
+		case '<':
+			if err := xml.Unmarshal([]byte(msg), new(struct{})); err != nil {
+				t.Errorf("Invalid XML, %v, %q", err, msg)
+			}
+		}
+		rendered = append(rendered, msg)
+	}
+	in.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	lines := 0
+	for s := bufio.NewScanner(f); s.Scan(); lines++ {
+		var truth GroundTruth
+		if err := json.Unmarshal(s.Bytes(), &truth); err != nil {
+			t.Fatal(err)
+		}
+		if int(truth.Seq) != lines+1 || len(truth.Injected) == 0 {
+			t.Errorf("Unexpected ground truth, %s", s.Text())
+		}
+		seq := map[byte]string{'{': `"pii_seq":%d}`, '<': `<Data Name="pii_seq">%d</Data>`, '1': ` pii_seq=%d`}[events[lines][0]]
+		if !strings.Contains(rendered[lines], fmt.Sprintf(seq, truth.Seq)) {
+			t.Errorf("Seq %d not found in %q", truth.Seq, rendered[lines])
+		}
+		for _, v := range truth.Injected {
+			// values are escaped in JSON and XML events
+			if value, _ := json.Marshal(v.Value); !strings.Contains(rendered[lines], v.Value) && !strings.Contains(rendered[lines], string(value)) {
+				t.Errorf("Value %q not found in %q", v.Value, rendered[lines])
+			}
+		}
+	}
+	if lines != len(events) {
+		t.Errorf("Expected %d ground truth records, got %d", len(events), lines)
+	}
+}
+
+func TestRepeatedKeys(t *testing.T) {
+	in, err := New(Config{Rate: 1, PerEvent: 4, Kinds: "email", Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repeated := false
+	for i := 0; i < 20; i++ {
+		msg, _ := in.Wrap(logtest.New(`{"msg":"sent"}`)).String()
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(msg), &fields); err != nil {
+			t.Fatalf("Invalid JSON, %q", msg)
+		}
+		if n := strings.Count(msg, `":`); n != len(fields) {
+			t.Errorf("Duplicate keys, %q", msg)
+		}
+		_, ok := fields["email_2"]
+		repeated = repeated || ok
+	}
+	if !repeated {
+		t.Error("Expected events with several email values")
+	}
+}
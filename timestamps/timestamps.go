@@ -0,0 +1,172 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timestamps
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// Config configures the timestamps of the events of a stream.
+type Config struct {
+	// Offset shifts every timestamp, simulating a skewed clock.
+	Offset time.Duration
+	// Jitter shifts timestamps randomly by up to +-Jitter, so events arrive
+	// out of order.
+	Jitter time.Duration
+	// LateFraction is the fraction of events delayed by LateMin to LateMax.
+	LateFraction float64
+	LateMin      time.Duration
+	LateMax      time.Duration
+	// FutureFraction is the fraction of events dated FutureMin to FutureMax
+	// ahead.
+	FutureFraction float64
+	FutureMin      time.Duration
+	FutureMax      time.Duration
+	// Timezone is the location of the timestamps, an IANA name like
+	// Asia/Tokyo, Local, UTC or a fixed offset like +05:30. Timestamps keep
+	// the location of the format if empty.
+	Timezone string
+	// Seed makes the timestamps reproducible, a random seed is used if 0.
+	Seed int64
+}
+
+// ConfigFromViper returns the config of the [<section>.timestamps] section,
+// the keys it does not set come from the [timestamps] section.
+func ConfigFromViper(section string) Config {
+	key := func(name string) string {
+		if k := section + ".timestamps." + name; section != "" && conf.Viper.IsSet(k) {
+			return k
+		}
+		return "timestamps." + name
+	}
+	return Config{
+		Offset:         conf.Viper.GetDuration(key("offset")),
+		Jitter:         conf.Viper.GetDuration(key("jitter")),
+		LateFraction:   conf.Viper.GetFloat64(key("late_fraction")),
+		LateMin:        conf.Viper.GetDuration(key("late_min")),
+		LateMax:        conf.Viper.GetDuration(key("late_max")),
+		FutureFraction: conf.Viper.GetFloat64(key("future_fraction")),
+		FutureMin:      conf.Viper.GetDuration(key("future_min")),
+		FutureMax:      conf.Viper.GetDuration(key("future_max")),
+		Timezone:       conf.Viper.GetString(key("timezone")),
+		Seed:           conf.Viper.GetInt64(key("seed")),
+	}
+}
+
+// Policy stamps events with skewed, jittered, late or future timestamps.
+type Policy struct {
+	config   Config
+	location *time.Location
+
+	m sync.Mutex
+	r *rand.Rand
+}
+
+// FromConfig returns the Policy of the events of a stream configured by the
+// [<section>.timestamps] section, or by the [timestamps] section if it is
+// enabled. It returns nil if neither configures one.
+func FromConfig(section string) (*Policy, error) {
+	if len(conf.Viper.GetStringMap(section+".timestamps")) == 0 && !conf.Viper.GetBool("timestamps.enabled") {
+		return nil, nil
+	}
+	return New(ConfigFromViper(section))
+}
+
+func New(config Config) (*Policy, error) {
+	if config.Jitter < 0 {
+		return nil, fmt.Errorf("jitter must not be negative, got %v", config.Jitter)
+	}
+	if config.LateFraction < 0 || config.FutureFraction < 0 || config.LateFraction+config.FutureFraction > 1 {
+		return nil, fmt.Errorf("late and future fractions must be between 0 and 1 in total, got %v and %v", config.LateFraction, config.FutureFraction)
+	}
+	if config.LateFraction > 0 && (config.LateMin <= 0 || config.LateMax < config.LateMin) {
+		return nil, fmt.Errorf("invalid late delays %v..%v", config.LateMin, config.LateMax)
+	}
+	if config.FutureFraction > 0 && (config.FutureMin <= 0 || config.FutureMax < config.FutureMin) {
+		return nil, fmt.Errorf("invalid future offsets %v..%v", config.FutureMin, config.FutureMax)
+	}
+
+	p := &Policy{config: config}
+
+	if tz := strings.TrimSpace(config.Timezone); tz != "" {
+		if offset, err := time.Parse("-07:00", tz); err == nil {
+			p.location = offset.Location()
+		} else if p.location, err = time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", tz, err)
+		}
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	p.r = rand.New(rand.NewSource(seed))
+
+	return p, nil
+}
+
+// logUniform returns a duration between min and max, short ones as likely as
+// long ones in proportion, e.g. minutes as likely as hours.
+func (p *Policy) logUniform(min, max time.Duration) time.Duration {
+	return time.Duration(float64(min) * math.Pow(float64(max)/float64(min), p.r.Float64()))
+}
+
+// Time returns the timestamp of an event created at now.
+func (p *Policy) Time(now time.Time) time.Time {
+	p.m.Lock()
+	defer p.m.Unlock()
+
+	t := now.Add(p.config.Offset)
+	if p.config.Jitter > 0 {
+		t = t.Add(time.Duration((2*p.r.Float64() - 1) * float64(p.config.Jitter)))
+	}
+
+	switch x := p.r.Float64(); {
+	case x < p.config.LateFraction:
+		t = t.Add(-p.logUniform(p.config.LateMin, p.config.LateMax))
+	case x < p.config.LateFraction+p.config.FutureFraction:
+		t = t.Add(p.logUniform(p.config.FutureMin, p.config.FutureMax))
+	}
+
+	if p.location != nil {
+		t = t.In(p.location)
+	}
+	return t
+}
+
+// Apply stamps l with the timestamp of the policy if its format has one,
+// shifting the time of the event, or now if the event has none. A nil Policy
+// returns l unchanged.
+func (p *Policy) Apply(l log.Log, now time.Time) log.Log {
+	if p == nil || l == nil {
+		return l
+	}
+	if ts, ok := l.(log.Timestamper); ok {
+		t := ts.Timestamp()
+		if t.IsZero() {
+			t = now
+		}
+		ts.SetTime(p.Time(t))
+	}
+	return l
+}
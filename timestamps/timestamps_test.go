@@ -0,0 +1,89 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package timestamps
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-logging/log-generator/formats/web"
+	"github.com/kube-logging/log-generator/log"
+)
+
+func TestPolicy(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+
+	for name, test := range map[string]struct {
+		config   Config
+		min, max time.Duration
+	}{
+		"skew":   {Config{Offset: -90 * time.Second}, -90 * time.Second, -90 * time.Second},
+		"jitter": {Config{Jitter: 2 * time.Second}, -2 * time.Second, 2 * time.Second},
+		"late":   {Config{LateFraction: 1, LateMin: time.Minute, LateMax: 2 * time.Hour}, -2 * time.Hour, -time.Minute},
+		"future": {Config{FutureFraction: 1, FutureMin: time.Minute, FutureMax: time.Hour}, time.Minute, time.Hour},
+	} {
+		test.config.Seed = 1
+		p, err := New(test.config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 0; i < 100; i++ {
+			if d := p.Time(now).Sub(now); d < test.min || d > test.max {
+				t.Errorf("%s: expected a shift between %v and %v, got %v", name, test.min, test.max, d)
+			}
+		}
+	}
+
+	for _, config := range []Config{
+		{Jitter: -time.Second},
+		{LateFraction: 0.6, FutureFraction: 0.6, LateMin: time.Minute, LateMax: time.Hour, FutureMin: time.Minute, FutureMax: time.Hour},
+		{LateFraction: 0.1, LateMin: time.Hour, LateMax: time.Minute},
+		{Timezone: "Mars/Olympus_Mons"},
+	} {
+		if _, err := New(config); err == nil {
+			t.Errorf("Expected an error of %+v", config)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	p, err := New(Config{Offset: 24 * time.Hour, Timezone: "+05:30"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l, err := log.NewLogTemplate("nginx", web.TemplateFS, web.SampleData())
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, _ := p.Apply(l, time.Now()).String()
+	if !strings.Contains(msg, "[27/Jun/2011:01:30:04 +0530]") {
+		t.Errorf("Expected the time of the event shifted by a day in +05:30, got %q", msg)
+	}
+
+	d := web.SampleData()
+	d.Time = time.Time{}
+	l, err = log.NewLogTemplate("nginx", web.TemplateFS, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, _ = p.Apply(l, time.Now()).String()
+
+	want := time.Now().Add(24 * time.Hour).In(time.FixedZone("", 5*3600+1800)).Format("[02/Jan/2006:")
+	if !strings.Contains(msg, want) || !strings.Contains(msg, "+0530]") {
+		t.Errorf("Expected a timestamp of tomorrow in +05:30 of an event without time, got %q", msg)
+	}
+}
@@ -0,0 +1,187 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kube-logging/log-generator/formats/tracing"
+	"github.com/kube-logging/log-generator/formats/web"
+	"github.com/kube-logging/log-generator/incidents"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// Event is an event logged by a service of the topology.
+type Event struct {
+	Service string
+	Log     log.Log
+}
+
+var errorMessages = []string{
+	"database query failed: context deadline exceeded",
+	"connection refused",
+	"internal error: nil pointer dereference",
+	"too many open files",
+	"circuit breaker is open",
+}
+
+type request struct {
+	t      *Topology
+	client web.TemplateData
+	events []Event
+	err    error
+}
+
+type response struct {
+	code     int
+	duration time.Duration
+}
+
+// Request returns the events of a request of a client to the entry service,
+// in the order they are logged: the events of the services called come
+// before the ones of their callers.
+func (t *Topology) Request() ([]Event, error) {
+	client := web.RandomData()
+
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	q := &request{t: t, client: client}
+	q.serve(t.entry, nil, tracing.NewContext(t.r, true), client.Time)
+	return q.events, q.err
+}
+
+// lognormal returns a lognormal value with the given median.
+func (q *request) lognormal(median float64) float64 {
+	return median * math.Exp(0.5*q.t.r.NormFloat64())
+}
+
+// serve simulates s serving a request of caller, or of the client if caller is
+// nil, in the span c.
+func (q *request) serve(s, caller *service, c tracing.Context, start time.Time) response {
+	r := q.t.r
+	own := time.Duration(q.lognormal(float64(s.config.Latency)) * incidents.LatencyFactor(s.name))
+	elapsed := own / 2
+
+	method, path, code := "GET", fmt.Sprintf("/api/v1/%s", s.name), 200
+	if r.Intn(5) == 0 {
+		method = "POST"
+	}
+	if caller == nil {
+		method, path = q.client.Method, q.client.Path
+	}
+
+	failed, failedCode := "", 0
+	if caller == nil && q.client.Code >= 400 && q.client.Code < 500 {
+		// client errors are answered by the entry service
+		code = q.client.Code
+	} else {
+	calls:
+		for _, call := range s.calls {
+			for i := 0; i < call.n; i++ {
+				res := q.serve(call.service, s, c.Child(r), start.Add(elapsed))
+				elapsed += res.duration
+				if res.code >= 500 {
+					failed, failedCode = call.service.name, res.code
+					break calls
+				}
+			}
+		}
+	}
+	elapsed += own - own/2
+	end := start.Add(elapsed)
+
+	switch {
+	case failed != "":
+		// proxies pass the failure on as a bad gateway
+		code = 500
+		if s.config.AppLog == "" {
+			code = 502
+		}
+		q.appLog(s, "error", fmt.Sprintf("call to %s failed with status %d", failed, failedCode), end, c)
+	case code == 200 && r.Float64() < max(s.config.ErrorRate, incidents.ErrorRate(s.name)):
+		code = []int{500, 500, 500, 503}[r.Intn(4)]
+		q.appLog(s, "error", errorMessages[r.Intn(len(errorMessages))], end, c)
+	default:
+		q.appLog(s, "info", fmt.Sprintf("%s %s completed with status %d in %v", method, path, code, elapsed.Round(time.Millisecond)), end, c)
+	}
+
+	if s.config.AccessLog != "" {
+		q.accessLog(s, caller, method, path, code, start, elapsed, c)
+	}
+
+	return response{code: code, duration: elapsed}
+}
+
+func (q *request) appLog(s *service, level, msg string, now time.Time, c tracing.Context) {
+	if s.config.AppLog == "" || q.err != nil {
+		return
+	}
+	l, err := s.appLog(level, msg, now, c)
+	if err != nil {
+		q.err = err
+		return
+	}
+	q.events = append(q.events, Event{Service: s.name, Log: l})
+}
+
+func (q *request) accessLog(s, caller *service, method, path string, code int, start time.Time, elapsed time.Duration, c tracing.Context) {
+	if q.err != nil {
+		return
+	}
+	r := q.t.r
+
+	d := q.client
+	if caller != nil {
+		d = web.TemplateData{
+			Remote:            caller.ip,
+			Host:              "-",
+			User:              "-",
+			Referer:           "-",
+			Agent:             "Go-http-client/1.1",
+			HttpXForwardedFor: q.client.Remote,
+			RemotePort:        1024 + r.Intn(64511),
+			Scheme:            "http",
+			Protocol:          "HTTP/1.1",
+			Authority:         fmt.Sprintf("%s.default.svc.cluster.local:8080", s.name),
+			RequestID:         q.client.RequestID,
+		}
+		if method != "GET" {
+			d.BytesReceived = r.Intn(4096)
+		}
+	}
+	d.Time = start
+	d.Method, d.Path, d.Code = method, path, code
+	d.Size = 200 + int(q.lognormal(800))
+	if code >= 400 {
+		d.Size = 50 + r.Intn(200)
+	}
+	d.Duration = elapsed
+	d.UpstreamDuration = elapsed - min(elapsed/10, time.Millisecond)
+	d.UpstreamCluster = fmt.Sprintf("outbound|8080||%s.default.svc.cluster.local", s.name)
+	d.UpstreamHost = s.ip + ":8080"
+	d.ResponseFlags = "-"
+	d.RouteName = s.name
+	d.Context = c
+
+	l, err := log.NewLogTemplate(s.config.AccessLog, web.TemplateFS, d)
+	if err != nil {
+		q.err = err
+		return
+	}
+	q.events = append(q.events, Event{Service: s.name, Log: l})
+}
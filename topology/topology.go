@@ -0,0 +1,271 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kube-logging/log-generator/conf"
+	"github.com/kube-logging/log-generator/formats/golang"
+	"github.com/kube-logging/log-generator/formats/sysloglike"
+	"github.com/kube-logging/log-generator/formats/tracing"
+	"github.com/kube-logging/log-generator/formats/web"
+	"github.com/kube-logging/log-generator/log"
+)
+
+// DefaultLatency is the latency of services that do not configure one.
+const DefaultLatency = 10 * time.Millisecond
+
+// ServiceConfig configures a service of the topology.
+type ServiceConfig struct {
+	// AccessLog is the web format of the access log of the service, e.g.
+	// nginx or envoy.json, the service has no access log if empty.
+	AccessLog string
+	// AppLog is the format of the application log of the service, golang or
+	// a syslog format such as syslog.rfc5424, the service has no application
+	// log if empty.
+	AppLog string
+	// Calls lists the services called for every request in order, "name*n"
+	// calls a service n times.
+	Calls string
+	// ErrorRate is the fraction of the requests failing in the service
+	// itself.
+	ErrorRate float64
+	// Latency is the median time the service spends on a request itself.
+	Latency time.Duration
+	// Destinations are the destinations of the events of the service, the
+	// default destinations are used if empty.
+	Destinations []string
+}
+
+// Config configures the topology the requests flow through.
+type Config struct {
+	// Entry is the service receiving the requests of the clients.
+	Entry    string
+	Services map[string]ServiceConfig
+	// Seed makes the requests reproducible, a random seed is used if 0.
+	Seed int64
+}
+
+func ConfigFromViper() Config {
+	config := Config{
+		Entry:    conf.Viper.GetString("topology.entry"),
+		Services: map[string]ServiceConfig{},
+		Seed:     conf.Viper.GetInt64("topology.seed"),
+	}
+	for name := range conf.Viper.GetStringMap("topology.services") {
+		key := "topology.services." + name
+		latency := DefaultLatency
+		if conf.Viper.IsSet(key + ".latency") {
+			latency = conf.Viper.GetDuration(key + ".latency")
+		}
+		destinations := list(conf.Viper.GetString(key + ".destinations"))
+		if len(destinations) == 0 {
+			destinations = list(conf.Viper.GetString("topology.destinations"))
+		}
+		config.Services[name] = ServiceConfig{
+			AccessLog:    conf.Viper.GetString(key + ".access_log"),
+			AppLog:       conf.Viper.GetString(key + ".app_log"),
+			Calls:        conf.Viper.GetString(key + ".calls"),
+			ErrorRate:    conf.Viper.GetFloat64(key + ".error_rate"),
+			Latency:      latency,
+			Destinations: destinations,
+		}
+	}
+	return config
+}
+
+func list(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+type call struct {
+	service *service
+	n       int
+}
+
+type service struct {
+	name   string
+	config ServiceConfig
+	calls  []call
+	// address of the pods of the service
+	ip  string
+	pid int
+}
+
+// Topology generates the events of requests flowing through a graph of
+// services: the access logs and application logs of every service a request
+// reaches, with consistent status codes, durations, request IDs and trace
+// context. A failure of a service fails every service upstream of it.
+type Topology struct {
+	m        sync.Mutex
+	r        *rand.Rand
+	entry    *service
+	services map[string]*service
+}
+
+// FromConfig returns the Topology configured by the [topology] config
+// section, or nil if it is disabled.
+func FromConfig() (*Topology, error) {
+	if !conf.Viper.GetBool("topology.enabled") {
+		return nil, nil
+	}
+	return New(ConfigFromViper())
+}
+
+func New(config Config) (*Topology, error) {
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	t := &Topology{
+		r:        rand.New(rand.NewSource(seed)),
+		services: map[string]*service{},
+	}
+
+	names := make([]string, 0, len(config.Services))
+	for name := range config.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		s := config.Services[name]
+		if err := validateService(s); err != nil {
+			return nil, fmt.Errorf("service %q: %w", name, err)
+		}
+		t.services[name] = &service{
+			name:   name,
+			config: s,
+			ip:     fmt.Sprintf("10.42.%d.%d", t.r.Intn(16), 1+t.r.Intn(254)),
+			pid:    1 + t.r.Intn(32767),
+		}
+	}
+
+	for _, name := range names {
+		s := t.services[name]
+		for _, item := range strings.Split(s.config.Calls, ",") {
+			item = strings.TrimSpace(item)
+			if item == "" {
+				continue
+			}
+			callee, n := item, 1
+			if c, count, ok := strings.Cut(item, "*"); ok {
+				var err error
+				if n, err = strconv.Atoi(strings.TrimSpace(count)); err != nil || n < 1 {
+					return nil, fmt.Errorf("service %q: invalid number of calls of %q", name, c)
+				}
+				callee = strings.TrimSpace(c)
+			}
+			downstream, ok := t.services[callee]
+			if !ok {
+				return nil, fmt.Errorf("service %q: calls unknown service %q", name, callee)
+			}
+			s.calls = append(s.calls, call{service: downstream, n: n})
+		}
+	}
+
+	var ok bool
+	if t.entry, ok = t.services[config.Entry]; !ok {
+		return nil, fmt.Errorf("entry service %q is not defined", config.Entry)
+	}
+	if err := checkCycles(t.entry, map[*service]bool{}); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func validateService(s ServiceConfig) error {
+	if s.ErrorRate < 0 || s.ErrorRate > 1 {
+		return fmt.Errorf("error rate must be between 0 and 1, got %v", s.ErrorRate)
+	}
+	if s.Latency < 0 {
+		return fmt.Errorf("latency must not be negative, got %v", s.Latency)
+	}
+	if s.AccessLog != "" {
+		if _, err := log.NewLogTemplate(s.AccessLog, web.TemplateFS, web.SampleData()); err != nil {
+			return fmt.Errorf("invalid access log format: %w", err)
+		}
+	}
+	switch {
+	case s.AppLog == "", s.AppLog == "golang":
+	case strings.HasPrefix(s.AppLog, "syslog."):
+		if _, err := sysloglike.NewSyslogMessage(s.AppLog, 16, 6, time.Now(), "", "", 1, ""); err != nil {
+			return fmt.Errorf("invalid application log format: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown application log format %q", s.AppLog)
+	}
+	return nil
+}
+
+// checkCycles returns an error if a service calls itself directly or through
+// other services.
+func checkCycles(s *service, path map[*service]bool) error {
+	if path[s] {
+		return fmt.Errorf("service %q calls itself", s.name)
+	}
+	path[s] = true
+	defer delete(path, s)
+	for _, c := range s.calls {
+		if err := checkCycles(c.service, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Destinations returns the destinations of the events of the services.
+func (t *Topology) Destinations() map[string][]string {
+	destinations := map[string][]string{}
+	for name, s := range t.services {
+		destinations[name] = s.config.Destinations
+	}
+	return destinations
+}
+
+// appLog returns an application log event of s.
+func (s *service) appLog(level string, msg string, now time.Time, c tracing.Context) (log.Log, error) {
+	if strings.HasPrefix(s.config.AppLog, "syslog.") {
+		severity := 6
+		if level == "error" {
+			severity = 3
+		}
+		return sysloglike.NewTracedSyslogMessage(s.config.AppLog, 16, severity, now, s.name+"-"+strconv.Itoa(s.pid%1000), s.name, s.pid, msg, c)
+	}
+	l := &golang.GolangLog{
+		Application: s.name,
+		Environment: "production",
+		Component:   "server",
+		Level:       level,
+		MSG:         msg,
+		Context:     c,
+	}
+	l.SetTime(now)
+	return l, nil
+}
@@ -0,0 +1,99 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package topology
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kube-logging/log-generator/conf"
+)
+
+func TestRequest(t *testing.T) {
+	conf.Init()
+
+	topo, err := New(Config{
+		Entry: "frontend",
+		Services: map[string]ServiceConfig{
+			"frontend": {AccessLog: "nginx", Calls: "checkout", Latency: time.Millisecond},
+			"checkout": {AccessLog: "envoy.json", AppLog: "golang", Calls: "payment*2,catalog", Latency: time.Millisecond},
+			"payment":  {AppLog: "syslog.rfc5424", ErrorRate: 1, Latency: time.Millisecond},
+			"catalog":  {AppLog: "golang", Latency: time.Millisecond},
+		},
+		Seed: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	traceID := regexp.MustCompile(`[0-9a-f]{32}`)
+	checked := 0
+	for i := 0; i < 20; i++ {
+		events, err := topo.Request()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry := events[len(events)-1]
+		if code := entry.Log.Labels()["severity"]; strings.HasPrefix(code, "4") {
+			// client errors do not reach the other services
+			continue
+		}
+		checked++
+
+		var services []string
+		for _, e := range events {
+			services = append(services, e.Service)
+		}
+		// payment fails at the first call, so checkout does not call it
+		// again nor catalog
+		if got := strings.Join(services, ","); got != "payment,checkout,checkout,frontend" {
+			t.Fatalf("Unexpected events of services %s", got)
+		}
+
+		if code := events[2].Log.Labels()["severity"]; code != "500" {
+			t.Errorf("Expected checkout to answer 500, got %s", code)
+		}
+		if code := entry.Log.Labels()["severity"]; code != "502" {
+			t.Errorf("Expected frontend to answer 502, got %s", code)
+		}
+
+		first, _ := events[0].Log.String()
+		id := traceID.FindString(first)
+		for _, e := range events {
+			if msg, _ := e.Log.String(); id == "" || !strings.Contains(msg, id) {
+				t.Errorf("Expected trace ID %q in %q", id, msg)
+			}
+		}
+	}
+	if checked == 0 {
+		t.Errorf("No request reached the services")
+	}
+}
+
+func TestInvalidTopology(t *testing.T) {
+	for name, services := range map[string]map[string]ServiceConfig{
+		"cycle":          {"frontend": {Calls: "backend"}, "backend": {Calls: "frontend"}},
+		"unknown callee": {"frontend": {Calls: "backend"}},
+		"unknown format": {"frontend": {AccessLog: "unknown"}},
+		"fan-out":        {"frontend": {Calls: "backend*0"}, "backend": {}},
+		"no entry":       {"backend": {}},
+	} {
+		if _, err := New(Config{Entry: "frontend", Services: services}); err == nil {
+			t.Errorf("Expected an error of the %s topology", name)
+		}
+	}
+}
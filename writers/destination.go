@@ -0,0 +1,79 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"fmt"
+)
+
+type DestinationConfig struct {
+	Type    string              `json:"type"`
+	Network string              `json:"network,omitempty"`
+	Address string              `json:"address,omitempty"`
+	File    FileLogWriterConfig `json:"-"`
+	// Compression and batching of the file and stdout destinations
+	Output OutputConfig `json:"-"`
+	// SYSLOG_IDENTIFIER and additional fields of journald entries
+	Identifier string            `json:"-"`
+	Fields     map[string]string `json:"-"`
+	// Maximum number of events waiting for an acknowledgement
+	Window           int       `json:"-"`
+	CompressionLevel int       `json:"-"`
+	TLS              TLSConfig `json:"-"`
+}
+
+func NewDestination(config DestinationConfig) (LogWriter, error) {
+	switch config.Type {
+	case "stdout":
+		return NewStdoutWriter(config.Output), nil
+	case "file":
+		if config.File.Path == "" {
+			return nil, fmt.Errorf("file destination requires a path")
+		}
+		config.File.Output = config.Output
+		return NewFileWriter(config.File), nil
+	case "network":
+		if config.Network == "" || config.Address == "" {
+			return nil, fmt.Errorf("network destination requires network and address")
+		}
+		return NewNetworkWriter(config.Network, config.Address), nil
+	case "unix", "unixgram":
+		if config.Address == "" {
+			return nil, fmt.Errorf("%s destination requires the socket path as address", config.Type)
+		}
+		return NewNetworkWriter(config.Type, config.Address), nil
+	case "relp":
+		if config.Address == "" {
+			return nil, fmt.Errorf("relp destination requires an address")
+		}
+		return NewRELPWriter(config.Address, config.Window), nil
+	case "lumberjack":
+		if config.Address == "" {
+			return nil, fmt.Errorf("lumberjack destination requires an address")
+		}
+		if config.CompressionLevel < 0 || config.CompressionLevel > 9 {
+			return nil, fmt.Errorf("lumberjack compression level must be between 0 and 9, got %d", config.CompressionLevel)
+		}
+		tlsConfig, err := config.TLS.Build()
+		if err != nil {
+			return nil, err
+		}
+		return NewLumberjackWriter(config.Address, config.Window, config.CompressionLevel, tlsConfig), nil
+	case "journald":
+		return NewJournaldWriter(config.Address, config.Identifier, config.Fields), nil
+	default:
+		return nil, fmt.Errorf("invalid destination type %q", config.Type)
+	}
+}
@@ -33,28 +33,44 @@ type FileLogWriterConfig struct {
 	DirMode        os.FileMode
 	FileMode       os.FileMode
 	SyncAfterWrite bool
+	Output         OutputConfig
 }
 
 type FileLogWriter struct {
 	config FileLogWriterConfig
 	file   *os.File
+	output *output
 	mu     sync.Mutex
 	closed bool
+	done   chan struct{}
 }
 
 func NewFileWriter(config FileLogWriterConfig) LogWriter {
 	flw := &FileLogWriter{
 		config: config,
+		done:   make(chan struct{}),
 	}
 	if err := flw.openLocked(); err != nil {
 		logger.Fatalf("failed to open log file: %v", err)
 	}
 
+	if config.Output.Buffered() {
+		go flushPeriodically(config.Output.FlushInterval, flw.done, func() {
+			flw.mu.Lock()
+			defer flw.mu.Unlock()
+			if !flw.closed {
+				flw.flushLocked()
+			}
+		})
+	}
+
 	return flw
 }
 
 func (flw *FileLogWriter) Send(l log.Log) {
 	msg, size := l.String()
+	// runes of the event, like size without its framing
+	count := runes(msg)
 	if l.IsFramed() {
 		msg = fmt.Sprintf("%d %s", len(msg), msg)
 	}
@@ -76,13 +92,14 @@ func (flw *FileLogWriter) Send(l log.Log) {
 		}
 	}
 
-	_, err := flw.file.WriteString(msg)
+	err := flw.output.WriteString(msg)
 	if err != nil {
 		logger.Errorf("error writing to file %s: %v", flw.config.Path, err)
 		return
 	}
 
-	if flw.config.SyncAfterWrite {
+	// batched events are synced when flushed
+	if flw.config.SyncAfterWrite && flw.config.Output.BatchSize == 0 {
 		if err := flw.file.Sync(); err != nil {
 			logger.Errorf("error syncing file %s: %v", flw.config.Path, err)
 		}
@@ -90,6 +107,7 @@ func (flw *FileLogWriter) Send(l log.Log) {
 
 	metrics.EventEmitted.With(l.Labels()).Inc()
 	metrics.EventEmittedBytes.With(l.Labels()).Add(size)
+	metrics.EventEmittedRunes.With(l.Labels()).Add(count)
 }
 
 func (flw *FileLogWriter) Close() {
@@ -100,8 +118,12 @@ func (flw *FileLogWriter) Close() {
 		return
 	}
 	flw.closed = true
+	close(flw.done)
 
 	if flw.file != nil {
+		if err := flw.output.Close(); err != nil {
+			logger.Errorf("error flushing file %s on close: %v", flw.config.Path, err)
+		}
 		if err := flw.file.Sync(); err != nil {
 			logger.Errorf("error syncing file %s on close: %v", flw.config.Path, err)
 		}
@@ -112,8 +134,27 @@ func (flw *FileLogWriter) Close() {
 	}
 }
 
+func (flw *FileLogWriter) flushLocked() {
+	if flw.file == nil {
+		return
+	}
+
+	if err := flw.output.Flush(); err != nil {
+		logger.Errorf("error flushing file %s: %v", flw.config.Path, err)
+		return
+	}
+
+	if flw.config.SyncAfterWrite {
+		if err := flw.file.Sync(); err != nil {
+			logger.Errorf("error syncing file %s: %v", flw.config.Path, err)
+		}
+	}
+}
+
 func (flw *FileLogWriter) openLocked() error {
 	if flw.file != nil {
+		// the pending events still belong to the old (rotated) file
+		_ = flw.output.Close()
 		_ = flw.file.Sync()
 		_ = flw.file.Close()
 		flw.file = nil
@@ -150,9 +191,15 @@ func (flw *FileLogWriter) openLocked() error {
 	if err != nil {
 		return fmt.Errorf("failed to open file %s: %w", flw.config.Path, err)
 	}
+	o, err := newOutput(file, flw.config.Output)
+	if err != nil {
+		file.Close()
+		return err
+	}
 	flw.file = file
+	flw.output = o
 
-	logger.Infof("Opened log file: %s (append=%v, sync=%v)", flw.config.Path, flw.config.Append, flw.config.SyncAfterWrite)
+	logger.Infof("Opened log file: %s (append=%v, sync=%v, compression=%q, batch=%d)", flw.config.Path, flw.config.Append, flw.config.SyncAfterWrite, flw.config.Output.Compression, flw.config.Output.BatchSize)
 	return nil
 }
 
@@ -0,0 +1,242 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/log"
+	"github.com/kube-logging/log-generator/metrics"
+)
+
+const DefaultJournaldSocket = "/run/systemd/journal/socket"
+
+// JournaldLogWriter sends events to systemd-journald using its native
+// protocol: one datagram per entry made of FIELD=value lines.
+type JournaldLogWriter struct {
+	address    string
+	identifier string
+	fields     []string
+	conn       *net.UnixConn
+}
+
+func NewJournaldWriter(address string, identifier string, fields map[string]string) LogWriter {
+	if address == "" {
+		address = DefaultJournaldSocket
+	}
+
+	jlw := &JournaldLogWriter{
+		address:    address,
+		identifier: identifier,
+	}
+
+	for k, v := range fields {
+		name := journaldFieldName(k)
+		if name == "" {
+			logger.Warnf("Ignoring journald field %q without a valid name", k)
+			continue
+		}
+		if name != k {
+			logger.Warnf("Sending journald field %q as %s", k, name)
+		}
+		jlw.fields = append(jlw.fields, journaldField(name, v))
+	}
+	sort.Strings(jlw.fields)
+
+	jlw.reconnect()
+	return jlw
+}
+
+func (jlw *JournaldLogWriter) Send(l log.Log) {
+	msg, size := l.String()
+
+	var b bytes.Buffer
+	b.WriteString(journaldField("MESSAGE", msg))
+	b.WriteString(journaldField("PRIORITY", strconv.Itoa(journaldPriority(l.Labels()["severity"]))))
+	if jlw.identifier != "" {
+		b.WriteString(journaldField("SYSLOG_IDENTIFIER", jlw.identifier))
+	}
+	for _, f := range jlw.fields {
+		b.WriteString(f)
+	}
+
+	for {
+		err := jlw.write(b.Bytes())
+		if err == nil {
+			break
+		}
+		if errors.Is(err, errJournaldEntry) {
+			logger.Errorf("Dropping journald entry of %d bytes (%q)", b.Len(), err.Error())
+			return
+		}
+		logger.Errorf("Error sending message to journald (%q), reconnecting...", err.Error())
+		jlw.reconnect()
+	}
+
+	metrics.EventEmitted.With(l.Labels()).Inc()
+	metrics.EventEmittedBytes.With(l.Labels()).Add(size)
+	metrics.EventEmittedRunes.With(l.Labels()).Add(runes(msg))
+}
+
+func (jlw *JournaldLogWriter) Close() {
+	if jlw.conn != nil {
+		jlw.conn.Close()
+	}
+}
+
+func (jlw *JournaldLogWriter) write(data []byte) error {
+	_, err := jlw.conn.Write(data)
+	if err == nil || !errors.Is(err, syscall.EMSGSIZE) && !errors.Is(err, syscall.ENOBUFS) {
+		return err
+	}
+
+	// Entries larger than the socket buffer are passed in a file descriptor.
+	f, err := os.CreateTemp("/dev/shm", "loggen-journal-")
+	if err != nil {
+		return fmt.Errorf("%w: %v", errJournaldEntry, err)
+	}
+	defer f.Close()
+
+	if err := os.Remove(f.Name()); err != nil {
+		return fmt.Errorf("%w: %v", errJournaldEntry, err)
+	}
+	if _, err := f.Write(data); err != nil {
+		return fmt.Errorf("%w: %v", errJournaldEntry, err)
+	}
+
+	_, _, err = jlw.conn.WriteMsgUnix(nil, syscall.UnixRights(int(f.Fd())), nil)
+	if err != nil && !isConnectionError(err) {
+		return fmt.Errorf("%w: %v", errJournaldEntry, err)
+	}
+	return err
+}
+
+// errJournaldEntry is returned for entries that cannot be sent whatever the
+// state of the connection, reconnecting does not help.
+var errJournaldEntry = errors.New("entry cannot be sent to journald")
+
+func isConnectionError(err error) bool {
+	for _, errno := range []syscall.Errno{syscall.ECONNREFUSED, syscall.ECONNRESET, syscall.ENOTCONN, syscall.EPIPE, syscall.ENOENT} {
+		if errors.Is(err, errno) {
+			return true
+		}
+	}
+	return errors.Is(err, net.ErrClosed)
+}
+
+func (jlw *JournaldLogWriter) reconnect() {
+	jlw.Close()
+
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = 0
+
+	backoff.RetryNotify(func() error {
+		logger.Infof("Connecting to journald at %s...", jlw.address)
+		conn, err := net.DialTimeout("unixgram", jlw.address, 5*time.Second)
+		if err != nil {
+			return err
+		}
+		jlw.conn = conn.(*net.UnixConn)
+		return nil
+	}, bo, func(err error, delay time.Duration) {
+		logger.Errorf("Error connecting to journald (%q), retrying in %s", err.Error(), delay.String())
+	})
+}
+
+// journaldFieldName turns a config key into a journald field name: upper case
+// letters, digits and underscores, not starting with an underscore, which
+// journald reserves for trusted fields. It returns "" if nothing is left or
+// the name starts with a digit, as journald drops such fields.
+func journaldFieldName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		default:
+			return '_'
+		}
+	}, key)
+	name = strings.TrimLeft(name, "_")
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		return ""
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// journaldField encodes a single field, using the binary form for values
+// containing new lines.
+func journaldField(name string, value string) string {
+	if !strings.Contains(value, "\n") {
+		return name + "=" + value + "\n"
+	}
+
+	size := make([]byte, 8)
+	binary.LittleEndian.PutUint64(size, uint64(len(value)))
+	return name + "\n" + string(size) + value + "\n"
+}
+
+// journaldPriority maps the severity label of the different log types (syslog
+// severities, level names, HTTP status codes) to a syslog priority.
+func journaldPriority(severity string) int {
+	if n, err := strconv.Atoi(severity); err == nil {
+		switch {
+		case n >= 0 && n <= 7:
+			return n
+		case n >= 500:
+			return 3
+		case n >= 400:
+			return 4
+		default:
+			return 6
+		}
+	}
+
+	switch strings.ToLower(severity) {
+	case "panic", "emerg", "emergency":
+		return 0
+	case "alert":
+		return 1
+	case "fatal", "crit", "critical":
+		return 2
+	case "error", "err":
+		return 3
+	case "warning", "warn":
+		return 4
+	case "notice":
+		return 5
+	case "debug", "trace":
+		return 7
+	default:
+		return 6
+	}
+}
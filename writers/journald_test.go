@@ -0,0 +1,83 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/kube-logging/log-generator/log/logtest"
+)
+
+func TestJournaldWriter(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "journal.socket")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	w := NewJournaldWriter(socket, "loggen", map[string]string{"env": "test"})
+	defer w.Close()
+
+	w.Send(&logtest.Log{Msg: "line one\nline two", Severity: "warning"})
+
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "MESSAGE\n\x11\x00\x00\x00\x00\x00\x00\x00line one\nline two\n" +
+		"PRIORITY=4\n" +
+		"SYSLOG_IDENTIFIER=loggen\n" +
+		"ENV=test\n"
+	if got := string(buf[:n]); got != expected {
+		t.Errorf("unexpected journald entry %q, expected %q", got, expected)
+	}
+}
+
+func TestJournaldFieldName(t *testing.T) {
+	for key, expected := range map[string]string{
+		"env":       "ENV",
+		"env-name":  "ENV_NAME",
+		"_internal": "INTERNAL",
+		"-":         "",
+		"app.éé":    "APP___",
+		"1x":        "",
+		"_2x":       "",
+		"x1":        "X1",
+	} {
+		if name := journaldFieldName(key); name != expected {
+			t.Errorf("field name of %q is %q, expected %q", key, name, expected)
+		}
+	}
+}
+
+func TestJournaldPriority(t *testing.T) {
+	for severity, expected := range map[string]int{
+		"3":     3,
+		"info":  6,
+		"error": 3,
+		"503":   3,
+		"404":   4,
+		"200":   6,
+	} {
+		if p := journaldPriority(severity); p != expected {
+			t.Errorf("priority of %q is %d, expected %d", severity, p, expected)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"crypto/tls"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/log"
+	"github.com/kube-logging/log-generator/metrics"
+)
+
+const (
+	DefaultLumberjackWindow           = 2048
+	DefaultLumberjackCompressionLevel = 3
+
+	lumberjackVersion       = '2'
+	lumberjackFlushInterval = time.Second
+	lumberjackAckTimeout    = 30 * time.Second
+	lumberjackCloseTimeout  = 10 * time.Second
+)
+
+type lumberjackEvent struct {
+	payload []byte
+	size    float64
+	runes   float64
+	labels  prometheus.Labels
+}
+
+// LumberjackLogWriter sends events as Filebeat does to Logstash beats inputs,
+// using the Lumberjack v2 protocol. Events are sent in batches of up to window
+// events, and a batch is sent again on a new connection until the receiver
+// acknowledges all of its events.
+type LumberjackLogWriter struct {
+	address          string
+	window           int
+	compressionLevel int
+	tlsConfig        *tls.Config
+	hostname         string
+	closeTimeout     time.Duration
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	batch  []lumberjackEvent
+	offset int
+	closed bool
+	done   chan struct{}
+}
+
+func NewLumberjackWriter(address string, window int, compressionLevel int, tlsConfig *tls.Config) LogWriter {
+	if window <= 0 {
+		window = DefaultLumberjackWindow
+	}
+
+	hostname, _ := os.Hostname()
+
+	ljw := &LumberjackLogWriter{
+		address:          address,
+		window:           window,
+		compressionLevel: compressionLevel,
+		tlsConfig:        tlsConfig,
+		hostname:         hostname,
+		closeTimeout:     lumberjackCloseTimeout,
+		done:             make(chan struct{}),
+	}
+
+	ljw.reconnect(0)
+	go ljw.flushPeriodically()
+
+	return ljw
+}
+
+func (ljw *LumberjackLogWriter) Send(l log.Log) {
+	msg, size := l.String()
+
+	ljw.mu.Lock()
+	defer ljw.mu.Unlock()
+
+	if ljw.closed {
+		logger.Warn("Attempted to write to closed LumberjackLogWriter")
+		return
+	}
+
+	payload, err := ljw.event(msg)
+	if err != nil {
+		logger.Errorf("error encoding lumberjack event: %v", err)
+		return
+	}
+
+	ljw.batch = append(ljw.batch, lumberjackEvent{
+		payload: payload,
+		size:    size,
+		runes:   runes(msg),
+		labels:  l.Labels(),
+	})
+
+	if len(ljw.batch) >= ljw.window {
+		ljw.flushLocked(time.Time{})
+	}
+}
+
+func (ljw *LumberjackLogWriter) Close() {
+	ljw.mu.Lock()
+	defer ljw.mu.Unlock()
+
+	if ljw.closed {
+		return
+	}
+	ljw.closed = true
+	close(ljw.done)
+
+	// the receiver may be gone, the last batch is dropped after a while
+	ljw.flushLocked(time.Now().Add(ljw.closeTimeout))
+	if ljw.conn != nil {
+		ljw.conn.Close()
+	}
+}
+
+// event returns the JSON document of a Filebeat log input event.
+func (ljw *LumberjackLogWriter) event(msg string) ([]byte, error) {
+	offset := ljw.offset
+	ljw.offset += len(msg) + 1
+
+	return json.Marshal(map[string]interface{}{
+		"@timestamp": log.Now().UTC().Format("2006-01-02T15:04:05.000Z"),
+		"@metadata": map[string]string{
+			"beat":    "filebeat",
+			"type":    "_doc",
+			"version": "8.15.0",
+		},
+		"message": msg,
+		"log": map[string]interface{}{
+			"offset": offset,
+			"file":   map[string]string{"path": "/var/log/loggen.log"},
+		},
+		"input": map[string]string{"type": "filestream"},
+		"host":  map[string]string{"name": ljw.hostname},
+		"agent": map[string]string{"type": "filebeat", "version": "8.15.0", "name": ljw.hostname},
+		"ecs":   map[string]string{"version": "8.0.0"},
+	})
+}
+
+func (ljw *LumberjackLogWriter) flushPeriodically() {
+	ticker := time.NewTicker(lumberjackFlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ljw.done:
+			return
+		case <-ticker.C:
+			ljw.mu.Lock()
+			if !ljw.closed {
+				ljw.flushLocked(time.Time{})
+			}
+			ljw.mu.Unlock()
+		}
+	}
+}
+
+// flushLocked sends the current batch, retrying on new connections until it
+// is acknowledged, or until the deadline if it is not zero.
+func (ljw *LumberjackLogWriter) flushLocked(deadline time.Time) {
+	if len(ljw.batch) == 0 {
+		return
+	}
+
+	frames, err := ljw.encodeBatch()
+	if err != nil {
+		logger.Errorf("error encoding lumberjack batch: %v", err)
+		ljw.batch = nil
+		return
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			logger.Warnf("Resending %d unacknowledged lumberjack events, the receiver may see duplicates", len(ljw.batch))
+			metrics.WriterResentEvents.WithLabelValues("lumberjack").Add(float64(len(ljw.batch)))
+		}
+
+		err := ljw.sendBatch(frames, deadline)
+		if err == nil {
+			break
+		}
+
+		var remaining time.Duration
+		if !deadline.IsZero() {
+			if remaining = time.Until(deadline); remaining <= 0 {
+				logger.Errorf("Dropping %d unacknowledged lumberjack events (%q)", len(ljw.batch), err.Error())
+				ljw.batch = nil
+				return
+			}
+		}
+		logger.Errorf("Error sending lumberjack batch (%q), reconnecting...", err.Error())
+		if err := ljw.reconnect(remaining); err != nil {
+			logger.Errorf("Dropping %d unacknowledged lumberjack events (%q)", len(ljw.batch), err.Error())
+			ljw.batch = nil
+			return
+		}
+	}
+
+	for _, e := range ljw.batch {
+		metrics.EventEmitted.With(e.labels).Inc()
+		metrics.EventEmittedBytes.With(e.labels).Add(e.size)
+		metrics.EventEmittedRunes.With(e.labels).Add(e.runes)
+	}
+	ljw.batch = nil
+}
+
+func (ljw *LumberjackLogWriter) encodeBatch() ([]byte, error) {
+	var data bytes.Buffer
+	for i, e := range ljw.batch {
+		data.Write([]byte{lumberjackVersion, 'J'})
+		binary.Write(&data, binary.BigEndian, uint32(i+1))
+		binary.Write(&data, binary.BigEndian, uint32(len(e.payload)))
+		data.Write(e.payload)
+	}
+
+	var frames bytes.Buffer
+	frames.Write([]byte{lumberjackVersion, 'W'})
+	binary.Write(&frames, binary.BigEndian, uint32(len(ljw.batch)))
+
+	if ljw.compressionLevel == 0 {
+		frames.Write(data.Bytes())
+		return frames.Bytes(), nil
+	}
+
+	var compressed bytes.Buffer
+	zw, err := zlib.NewWriterLevel(&compressed, ljw.compressionLevel)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := zw.Write(data.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	frames.Write([]byte{lumberjackVersion, 'C'})
+	binary.Write(&frames, binary.BigEndian, uint32(compressed.Len()))
+	frames.Write(compressed.Bytes())
+	return frames.Bytes(), nil
+}
+
+// sendBatch writes the frames and waits for the acknowledgement of the last
+// event, until the deadline if it is not zero. Acknowledgements of lower
+// sequence numbers are progress reports sent while the receiver is busy.
+func (ljw *LumberjackLogWriter) sendBatch(frames []byte, deadline time.Time) error {
+	ljw.conn.SetWriteDeadline(deadline)
+	if _, err := ljw.conn.Write(frames); err != nil {
+		return err
+	}
+
+	last := uint32(len(ljw.batch))
+	header := make([]byte, 6)
+	for {
+		readDeadline := time.Now().Add(lumberjackAckTimeout)
+		if !deadline.IsZero() && deadline.Before(readDeadline) {
+			readDeadline = deadline
+		}
+		ljw.conn.SetReadDeadline(readDeadline)
+		if _, err := io.ReadFull(ljw.reader, header); err != nil {
+			return err
+		}
+		if header[0] != lumberjackVersion || header[1] != 'A' {
+			return fmt.Errorf("unexpected lumberjack frame %q", header[:2])
+		}
+
+		seq := binary.BigEndian.Uint32(header[2:])
+		if seq == last {
+			return nil
+		}
+		if seq > last {
+			metrics.WriterUnexpectedAcks.WithLabelValues("lumberjack").Inc()
+			return fmt.Errorf("acknowledged sequence %d is beyond the batch size %d", seq, last)
+		}
+	}
+}
+
+// reconnect retries for up to maxElapsed, forever if 0.
+func (ljw *LumberjackLogWriter) reconnect(maxElapsed time.Duration) error {
+	if ljw.conn != nil {
+		ljw.conn.Close()
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = maxElapsed
+
+	return backoff.RetryNotify(func() error {
+		logger.Infof("Connecting to lumberjack server %s...", ljw.address)
+
+		dialer := &net.Dialer{Timeout: 5 * time.Second}
+		var conn net.Conn
+		var err error
+		if ljw.tlsConfig != nil {
+			conn, err = tls.DialWithDialer(dialer, "tcp", ljw.address, ljw.tlsConfig)
+		} else {
+			conn, err = dialer.Dial("tcp", ljw.address)
+		}
+		if err != nil {
+			return err
+		}
+
+		ljw.conn = conn
+		ljw.reader = bufio.NewReader(conn)
+		return nil
+	}, bo, func(err error, delay time.Duration) {
+		logger.Errorf("Error connecting to lumberjack server (%q), retrying in %s", err.Error(), delay.String())
+	})
+}
@@ -0,0 +1,194 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/kube-logging/log-generator/log/logtest"
+)
+
+// readLumberjackFrames decodes data frames, decompressing compressed ones.
+func readLumberjackFrames(r io.Reader, messages chan<- string) (uint32, error) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return 0, err
+	}
+
+	var n uint32
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return 0, err
+	}
+
+	switch header[1] {
+	case 'W':
+		return 0, nil
+	case 'J':
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return 0, err
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return 0, err
+		}
+		var event struct {
+			Message string `json:"message"`
+		}
+		if err := json.Unmarshal(payload, &event); err != nil {
+			return 0, err
+		}
+		messages <- event.Message
+		return n, nil
+	case 'C':
+		compressed := make([]byte, n)
+		if _, err := io.ReadFull(r, compressed); err != nil {
+			return 0, err
+		}
+		zr, err := zlib.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return 0, err
+		}
+		data, err := io.ReadAll(zr)
+		if err != nil {
+			return 0, err
+		}
+		var seq uint32
+		for br := bytes.NewReader(data); br.Len() > 0; {
+			if seq, err = readLumberjackFrames(br, messages); err != nil {
+				return 0, err
+			}
+		}
+		return seq, nil
+	}
+	return 0, fmt.Errorf("unexpected frame type %q", header[1])
+}
+
+func lumberjackServer(t *testing.T, dropFirstBatch bool) (string, <-chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	messages := make(chan string, 100)
+	go func() {
+		for session := 0; ; session++ {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			r := bufio.NewReader(conn)
+			for {
+				seq, err := readLumberjackFrames(r, messages)
+				if err != nil {
+					break
+				}
+				if seq == 0 {
+					// window frame
+					continue
+				}
+				if session == 0 && dropFirstBatch {
+					break
+				}
+				ack := []byte{'2', 'A', 0, 0, 0, 0}
+				binary.BigEndian.PutUint32(ack[2:], seq)
+				conn.Write(ack)
+			}
+			conn.Close()
+		}
+	}()
+
+	return l.Addr().String(), messages
+}
+
+func TestLumberjackWriter(t *testing.T) {
+	for _, level := range []int{0, DefaultLumberjackCompressionLevel} {
+		address, messages := lumberjackServer(t, false)
+
+		w := NewLumberjackWriter(address, 2, level, nil)
+		for _, msg := range []string{"one", "two", "three"} {
+			w.Send(logtest.New(msg))
+		}
+		w.Close()
+
+		for _, expected := range []string{"one", "two", "three"} {
+			if got := <-messages; got != expected {
+				t.Errorf("compression level %d: received %q, expected %q", level, got, expected)
+			}
+		}
+	}
+}
+
+func TestLumberjackWriterResendsAfterReconnect(t *testing.T) {
+	address, messages := lumberjackServer(t, true)
+
+	w := NewLumberjackWriter(address, 2, DefaultLumberjackCompressionLevel, nil)
+	w.Send(logtest.New("one"))
+	w.Send(logtest.New("two"))
+	w.Close()
+
+	for _, expected := range []string{"one", "two", "one", "two"} {
+		if got := <-messages; got != expected {
+			t.Errorf("received %q, expected %q", got, expected)
+		}
+	}
+}
+
+func TestLumberjackWriterCloseDeadline(t *testing.T) {
+	// a receiver that never acknowledges anything
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go io.Copy(io.Discard, conn)
+		}
+	}()
+
+	w := NewLumberjackWriter(l.Addr().String(), 2, 0, nil).(*LumberjackLogWriter)
+	w.closeTimeout = 200 * time.Millisecond
+	w.Send(logtest.New("one"))
+
+	started := time.Now()
+	w.Close()
+	if d := time.Since(started); d > 2*time.Second {
+		t.Errorf("Close took %s, expected it to give up after %s", d, w.closeTimeout)
+	}
+}
+
+func TestLumberjackCompressionLevel(t *testing.T) {
+	for _, level := range []int{-1, 10} {
+		_, err := NewDestination(DestinationConfig{Type: "lumberjack", Address: "127.0.0.1:5044", CompressionLevel: level})
+		if err == nil {
+			t.Errorf("Expected an error for compression level %d", level)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"github.com/kube-logging/log-generator/log"
+)
+
+// MultiLogWriter sends every event to all of its writers. The event is
+// rendered only once, so each writer receives exactly the same content.
+type MultiLogWriter struct {
+	writers []LogWriter
+}
+
+func NewMultiWriter(writers ...LogWriter) LogWriter {
+	if len(writers) == 1 {
+		return writers[0]
+	}
+
+	return &MultiLogWriter{
+		writers: writers,
+	}
+}
+
+func (mlw *MultiLogWriter) Send(l log.Log) {
+	rendered := log.Render(l)
+	for _, w := range mlw.writers {
+		w.Send(rendered)
+	}
+}
+
+// Close does nothing: the writers are shared by the destinations and closed
+// by their owner, once each.
+func (mlw *MultiLogWriter) Close() {}
@@ -0,0 +1,48 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"testing"
+
+	"github.com/kube-logging/log-generator/log/logtest"
+)
+
+func TestMultiWriter(t *testing.T) {
+	a, b, c := &logtest.Writer{}, &logtest.Writer{}, &logtest.Writer{}
+	w := NewMultiWriter(a, b, c)
+
+	l := &logtest.Log{}
+	w.Send(l)
+	w.Send(l)
+
+	if l.Renders != 2 {
+		t.Errorf("Expected an event to be rendered once, got %d renders for 2 events", l.Renders)
+	}
+	for _, r := range []*logtest.Writer{a, b, c} {
+		if len(r.Msgs) != 2 || r.Msgs[0] != "event 1" || r.Msgs[1] != "event 2" {
+			t.Errorf("Expected every writer to receive the same events, got %q", r.Msgs)
+		}
+	}
+
+	w.Close()
+	if a.Closed || b.Closed || c.Closed {
+		t.Error("Expected the shared writers to be left open")
+	}
+
+	if single := NewMultiWriter(a); single != LogWriter(a) {
+		t.Error("Expected a single writer to be used as is")
+	}
+}
@@ -44,10 +44,13 @@ func NewNetworkWriter(network string, address string) LogWriter {
 
 func (nlw *NetworkLogWriter) Send(l log.Log) {
 	msg, size := l.String()
+	// runes of the event, like size without its framing
+	count := runes(msg)
 
 	if l.IsFramed() {
 		msg = fmt.Sprintf("%d %s", len(msg), msg)
-	} else {
+	} else if nlw.network != "unixgram" {
+		// datagrams on /dev/log-style sockets carry exactly one message
 		msg += "\n"
 	}
 
@@ -71,6 +74,7 @@ func (nlw *NetworkLogWriter) Send(l log.Log) {
 
 	metrics.EventEmitted.With(l.Labels()).Inc()
 	metrics.EventEmittedBytes.With(l.Labels()).Add(size)
+	metrics.EventEmittedRunes.With(l.Labels()).Add(count)
 }
 
 func (nlw *NetworkLogWriter) Close() {
@@ -0,0 +1,139 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const DefaultFlushInterval = time.Second
+
+// OutputConfig controls how the file and stdout writers write rendered events.
+type OutputConfig struct {
+	// Compression of the output stream: "gzip", "zstd" or empty for none
+	Compression string
+	// Bytes of events to coalesce into a single write, 0 writes every event.
+	// Compressed events are written when the compressor emits a block or at
+	// the flush interval.
+	BatchSize int
+	// Maximum time an event is kept in the batch or the compressor
+	FlushInterval time.Duration
+}
+
+// Buffered tells whether events are kept before being written, other output
+// to the same stream would end up in the middle of them.
+func (c OutputConfig) Buffered() bool {
+	return c.Compression != "" || c.BatchSize > 0
+}
+
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+// output writes events to dst, compressing and batching them as configured.
+type output struct {
+	config OutputConfig
+	buf    *bufio.Writer
+	enc    compressor
+	w      io.Writer
+}
+
+func newOutput(dst io.Writer, config OutputConfig) (*output, error) {
+	o := &output{
+		config: config,
+		w:      dst,
+	}
+
+	if config.BatchSize > 0 {
+		o.buf = bufio.NewWriterSize(dst, config.BatchSize)
+		o.w = o.buf
+	}
+
+	switch config.Compression {
+	case "":
+	case "gzip":
+		o.enc = gzip.NewWriter(o.w)
+	case "zstd":
+		enc, err := zstd.NewWriter(o.w)
+		if err != nil {
+			return nil, err
+		}
+		o.enc = enc
+	default:
+		return nil, fmt.Errorf("invalid compression %q", config.Compression)
+	}
+	if o.enc != nil {
+		o.w = o.enc
+	}
+
+	return o, nil
+}
+
+func (o *output) WriteString(s string) error {
+	_, err := io.WriteString(o.w, s)
+	return err
+}
+
+func (o *output) Flush() error {
+	if o.enc != nil {
+		if err := o.enc.Flush(); err != nil {
+			return err
+		}
+	}
+	if o.buf != nil {
+		return o.buf.Flush()
+	}
+	return nil
+}
+
+// Close finishes the compressed stream and writes the pending events, but
+// leaves dst open.
+func (o *output) Close() error {
+	if o.enc != nil {
+		if err := o.enc.Close(); err != nil {
+			return err
+		}
+	}
+	if o.buf != nil {
+		return o.buf.Flush()
+	}
+	return nil
+}
+
+// flushPeriodically calls flush every interval until done is closed.
+func flushPeriodically(interval time.Duration, done <-chan struct{}, flush func()) {
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
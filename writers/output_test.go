@@ -0,0 +1,113 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/kube-logging/log-generator/log/logtest"
+)
+
+func TestOutputCompression(t *testing.T) {
+	decompress := map[string]func(io.Reader) (io.Reader, error){
+		"gzip": func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+		"zstd": func(r io.Reader) (io.Reader, error) { return zstd.NewReader(r) },
+	}
+
+	for compression, reader := range decompress {
+		var dst bytes.Buffer
+		o, err := newOutput(&dst, OutputConfig{Compression: compression})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := strings.Repeat("a compressible event\n", 1000)
+		for _, line := range strings.SplitAfter(expected, "\n") {
+			if err := o.WriteString(line); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := o.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		if dst.Len() >= len(expected)/10 {
+			t.Errorf("%s: expected events to be compressed in a stream, got %d bytes for %d", compression, dst.Len(), len(expected))
+		}
+		r, err := reader(&dst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != expected {
+			t.Errorf("%s: round trip lost events, got %d bytes, expected %d", compression, len(got), len(expected))
+		}
+	}
+}
+
+func TestOutputBatch(t *testing.T) {
+	var dst bytes.Buffer
+	o, err := newOutput(&dst, OutputConfig{BatchSize: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	o.WriteString("one\n")
+	o.WriteString("two\n")
+	if dst.Len() != 0 {
+		t.Errorf("Expected events to be kept until the batch is full, got %q", dst.String())
+	}
+	o.WriteString("three\nfour\n")
+	if dst.String() != "one\ntwo\nthree\nfour\n"[:16] {
+		t.Errorf("Expected a full batch to be written, got %q", dst.String())
+	}
+	o.Flush()
+	if dst.String() != "one\ntwo\nthree\nfour\n" {
+		t.Errorf("Expected Flush to write the batch, got %q", dst.String())
+	}
+}
+
+func TestFlushInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loggen.log")
+	w := NewFileWriter(FileLogWriterConfig{
+		Path:     path,
+		Create:   true,
+		FileMode: 0o600,
+		Output:   OutputConfig{BatchSize: 4096, FlushInterval: 50 * time.Millisecond},
+	})
+	defer w.Close()
+
+	w.Send(logtest.New("one"))
+	if b, _ := os.ReadFile(path); len(b) != 0 {
+		t.Errorf("Expected the event to be batched, got %q", b)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	if b, _ := os.ReadFile(path); string(b) != "one\n" {
+		t.Errorf("Expected the event to be flushed after the interval, got %q", b)
+	}
+}
@@ -0,0 +1,368 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	logger "github.com/sirupsen/logrus"
+
+	"github.com/kube-logging/log-generator/log"
+	"github.com/kube-logging/log-generator/metrics"
+)
+
+const (
+	DefaultRELPWindow = 128
+
+	relpOffers       = "relp_version=0\nrelp_software=log-generator\ncommands=syslog"
+	relpMaxTxnr      = 999999999
+	relpCloseTimeout = 10 * time.Second
+)
+
+type relpMessage struct {
+	txnr   int
+	msg    string
+	size   float64
+	labels prometheus.Labels
+}
+
+// RELPLogWriter sends events with the Reliable Event Logging Protocol. At most
+// window events are waiting for an acknowledgement at any time, and these are
+// sent again after a reconnect. Events are counted as emitted when the
+// receiver acknowledges them.
+type RELPLogWriter struct {
+	address string
+	window  int
+
+	mu        sync.Mutex
+	cond      *sync.Cond
+	conn      net.Conn
+	session   int
+	broken    bool
+	txnr      int
+	unacked   map[int]*relpMessage
+	closeTxnr int
+	closed    bool
+}
+
+func NewRELPWriter(address string, window int) LogWriter {
+	if window <= 0 {
+		window = DefaultRELPWindow
+	}
+
+	rlw := &RELPLogWriter{
+		address: address,
+		window:  window,
+		unacked: map[int]*relpMessage{},
+	}
+	rlw.cond = sync.NewCond(&rlw.mu)
+
+	rlw.mu.Lock()
+	defer rlw.mu.Unlock()
+	rlw.reconnectLocked(0)
+
+	return rlw
+}
+
+func (rlw *RELPLogWriter) Send(l log.Log) {
+	msg, size := l.String()
+
+	rlw.mu.Lock()
+	defer rlw.mu.Unlock()
+
+	if rlw.closed {
+		logger.Warn("Attempted to write to closed RELPLogWriter")
+		return
+	}
+
+	for {
+		if rlw.broken {
+			rlw.reconnectLocked(0)
+			continue
+		}
+		if len(rlw.unacked) < rlw.window {
+			break
+		}
+		rlw.cond.Wait()
+	}
+
+	rlw.sendLocked(&relpMessage{
+		msg:    msg,
+		size:   size,
+		labels: l.Labels(),
+	})
+}
+
+func (rlw *RELPLogWriter) Close() {
+	rlw.mu.Lock()
+	defer rlw.mu.Unlock()
+
+	if rlw.closed {
+		return
+	}
+	rlw.closed = true
+
+	end := time.Now().Add(relpCloseTimeout)
+	expired := false
+	deadline := time.AfterFunc(relpCloseTimeout, func() {
+		rlw.mu.Lock()
+		defer rlw.mu.Unlock()
+		expired = true
+		rlw.cond.Broadcast()
+	})
+	defer deadline.Stop()
+
+	// the unacknowledged messages are sent again once if the connection breaks
+	reconnected := false
+	for len(rlw.unacked) > 0 && !expired {
+		if !rlw.broken {
+			rlw.cond.Wait()
+			continue
+		}
+		remaining := time.Until(end)
+		if reconnected || remaining <= 0 || rlw.reconnectLocked(remaining) != nil {
+			break
+		}
+		reconnected = true
+	}
+	if len(rlw.unacked) > 0 {
+		logger.Errorf("Closing RELP connection to %s with %d unacknowledged messages", rlw.address, len(rlw.unacked))
+	}
+
+	if !rlw.broken && !expired {
+		rlw.closeTxnr = rlw.nextTxnr()
+		if _, err := io.WriteString(rlw.conn, relpFrame(rlw.closeTxnr, "close", "")); err == nil {
+			for rlw.closeTxnr != 0 && !rlw.broken && !expired {
+				rlw.cond.Wait()
+			}
+		}
+	}
+
+	rlw.session++
+	if rlw.conn != nil {
+		rlw.conn.Close()
+	}
+}
+
+func (rlw *RELPLogWriter) sendLocked(m *relpMessage) {
+	m.txnr = rlw.nextTxnr()
+	rlw.unacked[m.txnr] = m
+
+	if _, err := io.WriteString(rlw.conn, relpFrame(m.txnr, "syslog", m.msg)); err != nil {
+		logger.Errorf("Error sending RELP message (%q), reconnecting...", err.Error())
+		rlw.broken = true
+	}
+}
+
+func (rlw *RELPLogWriter) nextTxnr() int {
+	rlw.txnr++
+	if rlw.txnr > relpMaxTxnr {
+		rlw.txnr = 1
+	}
+	return rlw.txnr
+}
+
+// reconnectLocked opens a new session and sends the unacknowledged messages
+// of the previous one again. It retries for up to maxElapsed, forever if 0.
+func (rlw *RELPLogWriter) reconnectLocked(maxElapsed time.Duration) error {
+	if rlw.conn != nil {
+		rlw.conn.Close()
+	}
+	rlw.session++
+
+	pending := make([]*relpMessage, 0, len(rlw.unacked))
+	for _, m := range rlw.unacked {
+		pending = append(pending, m)
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].txnr < pending[j].txnr })
+
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = maxElapsed
+
+	err := backoff.RetryNotify(func() error {
+		logger.Infof("Connecting to RELP server %s...", rlw.address)
+		conn, err := net.DialTimeout("tcp", rlw.address, 5*time.Second)
+		if err != nil {
+			return err
+		}
+		rlw.txnr = 0
+		if err := rlw.openSession(conn); err != nil {
+			conn.Close()
+			return err
+		}
+		rlw.conn = conn
+		return nil
+	}, bo, func(err error, delay time.Duration) {
+		logger.Errorf("Error connecting to RELP server (%q), retrying in %s", err.Error(), delay.String())
+	})
+	if err != nil {
+		logger.Errorf("Giving up connecting to RELP server %s (%q)", rlw.address, err.Error())
+		return err
+	}
+
+	rlw.broken = false
+	rlw.unacked = map[int]*relpMessage{}
+	go rlw.readResponses(rlw.conn, rlw.session)
+
+	if len(pending) > 0 {
+		logger.Warnf("Resending %d unacknowledged RELP messages, the receiver may see duplicates", len(pending))
+		metrics.WriterResentEvents.WithLabelValues("relp").Add(float64(len(pending)))
+	}
+	for _, m := range pending {
+		rlw.sendLocked(m)
+	}
+	return nil
+}
+
+func (rlw *RELPLogWriter) openSession(conn net.Conn) error {
+	txnr := rlw.nextTxnr()
+	if _, err := io.WriteString(conn, relpFrame(txnr, "open", relpOffers)); err != nil {
+		return err
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	defer conn.SetReadDeadline(time.Time{})
+
+	// the reader is discarded, nothing is sent before the open response
+	rsp, command, data, err := readRELPFrame(bufio.NewReader(conn))
+	if err != nil {
+		return err
+	}
+	if rsp != txnr || command != "rsp" || !strings.HasPrefix(data, "200") {
+		return fmt.Errorf("unexpected response to open: %d %s %q", rsp, command, data)
+	}
+	return nil
+}
+
+func (rlw *RELPLogWriter) readResponses(conn net.Conn, session int) {
+	r := bufio.NewReader(conn)
+	for {
+		txnr, command, data, err := readRELPFrame(r)
+
+		rlw.mu.Lock()
+		if session != rlw.session {
+			rlw.mu.Unlock()
+			return
+		}
+
+		if err != nil {
+			logger.Errorf("Error reading RELP response (%q)", err.Error())
+			rlw.broken = true
+		} else if command == "serverclose" {
+			logger.Warnf("RELP server %s closed the connection", rlw.address)
+			rlw.broken = true
+		} else if command == "rsp" {
+			rlw.ackLocked(txnr, data)
+		}
+
+		rlw.cond.Broadcast()
+		broken := rlw.broken
+		rlw.mu.Unlock()
+
+		if broken {
+			conn.Close()
+			return
+		}
+	}
+}
+
+func (rlw *RELPLogWriter) ackLocked(txnr int, data string) {
+	if txnr == rlw.closeTxnr {
+		rlw.closeTxnr = 0
+		return
+	}
+
+	m, ok := rlw.unacked[txnr]
+	if !ok {
+		logger.Warnf("Unexpected RELP response for txnr %d: %q", txnr, data)
+		metrics.WriterUnexpectedAcks.WithLabelValues("relp").Inc()
+		return
+	}
+	delete(rlw.unacked, txnr)
+
+	if !strings.HasPrefix(data, "200") {
+		logger.Errorf("RELP message %d rejected: %q", txnr, data)
+		metrics.WriterRejectedEvents.WithLabelValues("relp").Inc()
+		return
+	}
+
+	metrics.EventEmitted.With(m.labels).Inc()
+	metrics.EventEmittedBytes.With(m.labels).Add(m.size)
+	metrics.EventEmittedRunes.With(m.labels).Add(runes(m.msg))
+}
+
+func relpFrame(txnr int, command string, data string) string {
+	if len(data) == 0 {
+		return fmt.Sprintf("%d %s 0\n", txnr, command)
+	}
+	return fmt.Sprintf("%d %s %d %s\n", txnr, command, len(data), data)
+}
+
+// readRELPFrame reads a TXNR SP COMMAND SP DATALEN [SP DATA] TRAILER frame.
+func readRELPFrame(r *bufio.Reader) (txnr int, command string, data string, err error) {
+	field, err := r.ReadString(' ')
+	if err != nil {
+		return
+	}
+	if txnr, err = strconv.Atoi(strings.TrimSpace(field)); err != nil {
+		return
+	}
+
+	if command, err = r.ReadString(' '); err != nil {
+		return
+	}
+	command = strings.TrimSpace(command)
+
+	var length strings.Builder
+	for {
+		var c byte
+		if c, err = r.ReadByte(); err != nil {
+			return
+		}
+		if c == ' ' || c == '\n' {
+			break
+		}
+		length.WriteByte(c)
+	}
+
+	size, err := strconv.Atoi(length.String())
+	if err != nil {
+		return
+	}
+	if size == 0 {
+		return
+	}
+
+	buf := make([]byte, size+1)
+	if _, err = io.ReadFull(r, buf); err != nil {
+		return
+	}
+	if buf[size] != '\n' {
+		err = fmt.Errorf("invalid RELP frame trailer %q", buf[size])
+		return
+	}
+	data = string(buf[:size])
+	return
+}
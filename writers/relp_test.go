@@ -0,0 +1,114 @@
+// Copyright © 2026 Kube logging authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writers
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/kube-logging/log-generator/log/logtest"
+)
+
+// relpServer accepts RELP sessions and acknowledges every message, except that
+// the first session is dropped after receiving dropAfter messages.
+func relpServer(t *testing.T, dropAfter int) (string, <-chan string) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	received := make(chan string, 100)
+	go func() {
+		for session := 0; ; session++ {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			r := bufio.NewReader(conn)
+			for n := 0; ; {
+				txnr, command, data, err := readRELPFrame(r)
+				if err != nil {
+					break
+				}
+				switch command {
+				case "open":
+					io.WriteString(conn, relpFrame(txnr, "rsp", "200 OK\nrelp_version=0\ncommands=syslog"))
+				case "syslog":
+					received <- data
+					if n++; session == 0 && n == dropAfter {
+						conn.Close()
+						continue
+					}
+					io.WriteString(conn, relpFrame(txnr, "rsp", "200 OK"))
+				case "close":
+					io.WriteString(conn, relpFrame(txnr, "rsp", ""))
+					io.WriteString(conn, relpFrame(0, "serverclose", ""))
+				}
+			}
+			conn.Close()
+		}
+	}()
+
+	return l.Addr().String(), received
+}
+
+func TestRELPWriter(t *testing.T) {
+	address, received := relpServer(t, 0)
+
+	w := NewRELPWriter(address, 2)
+	for _, msg := range []string{"one", "two", "three"} {
+		w.Send(logtest.New(msg))
+	}
+	w.Close()
+
+	for _, expected := range []string{"one", "two", "three"} {
+		if got := <-received; got != expected {
+			t.Errorf("received %q, expected %q", got, expected)
+		}
+	}
+}
+
+func TestRELPWriterResendsAfterReconnect(t *testing.T) {
+	address, received := relpServer(t, 1)
+
+	w := NewRELPWriter(address, 1)
+	w.Send(logtest.New("one"))
+	w.Send(logtest.New("two"))
+	w.Close()
+
+	for _, expected := range []string{"one", "one", "two"} {
+		if got := <-received; got != expected {
+			t.Errorf("received %q, expected %q", got, expected)
+		}
+	}
+}
+
+func TestRELPWriterResendsOnClose(t *testing.T) {
+	address, received := relpServer(t, 2)
+
+	w := NewRELPWriter(address, 2)
+	w.Send(logtest.New("one"))
+	w.Send(logtest.New("two"))
+	w.Close()
+
+	for _, expected := range []string{"one", "two", "two"} {
+		if got := <-received; got != expected {
+			t.Errorf("received %q, expected %q", got, expected)
+		}
+	}
+}
@@ -16,28 +16,90 @@ package writers
 
 import (
 	"fmt"
+	"os"
+	"sync"
+
+	logger "github.com/sirupsen/logrus"
 
 	"github.com/kube-logging/log-generator/log"
 	"github.com/kube-logging/log-generator/metrics"
 )
 
-type StdoutLogWriter struct{}
+type StdoutLogWriter struct {
+	output *output
+	mu     sync.Mutex
+	closed bool
+	done   chan struct{}
+}
+
+func NewStdoutWriter(config OutputConfig) LogWriter {
+	o, err := newOutput(os.Stdout, config)
+	if err != nil {
+		logger.Fatalf("failed to set up stdout: %v", err)
+	}
+
+	slw := &StdoutLogWriter{
+		output: o,
+		done:   make(chan struct{}),
+	}
+
+	if config.Buffered() {
+		go flushPeriodically(config.FlushInterval, slw.done, func() {
+			slw.mu.Lock()
+			defer slw.mu.Unlock()
+			if !slw.closed {
+				slw.flushLocked()
+			}
+		})
+	}
 
-func NewStdoutWriter() LogWriter {
-	return &StdoutLogWriter{}
+	return slw
 }
 
-func (*StdoutLogWriter) Send(l log.Log) {
+func (slw *StdoutLogWriter) Send(l log.Log) {
 	msg, size := l.String()
+	// runes of the event, like size without its framing
+	count := runes(msg)
 
 	if l.IsFramed() {
 		msg = fmt.Sprintf("%d %s", len(msg), msg)
 	}
 
-	fmt.Println(msg)
+	slw.mu.Lock()
+	defer slw.mu.Unlock()
+
+	if slw.closed {
+		logger.Warn("Attempted to write to closed StdoutLogWriter")
+		return
+	}
+
+	if err := slw.output.WriteString(msg + "\n"); err != nil {
+		logger.Errorf("error writing to stdout: %v", err)
+		return
+	}
 
 	metrics.EventEmitted.With(l.Labels()).Inc()
 	metrics.EventEmittedBytes.With(l.Labels()).Add(size)
+	metrics.EventEmittedRunes.With(l.Labels()).Add(count)
+}
+
+func (slw *StdoutLogWriter) Close() {
+	slw.mu.Lock()
+	defer slw.mu.Unlock()
+
+	if slw.closed {
+		return
+	}
+	slw.closed = true
+	close(slw.done)
+
+	if err := slw.output.Close(); err != nil {
+		logger.Errorf("error flushing stdout: %v", err)
+	}
 }
 
-func (*StdoutLogWriter) Close() {}
+func (slw *StdoutLogWriter) flushLocked() {
+	if err := slw.output.Flush(); err != nil {
+		logger.Errorf("error flushing stdout: %v", err)
+	}
+}
@@ -14,9 +14,19 @@
 
 package writers
 
-import "github.com/kube-logging/log-generator/log"
+import (
+	"unicode/utf8"
+
+	"github.com/kube-logging/log-generator/log"
+)
 
 type LogWriter interface {
 	Send(log.Log)
 	Close()
 }
+
+// runes returns the number of characters of msg, counted by the
+// loggen_event_runes_total metric next to its bytes.
+func runes(msg string) float64 {
+	return float64(utf8.RuneCountInString(msg))
+}